@@ -0,0 +1,146 @@
+package intervalset
+
+import (
+	"errors"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	// ErrExhausted is returned when the allocator does not have enough free values.
+	ErrExhausted = errors.New("intervalset: no free values left")
+
+	// ErrUnavailable is returned when a value is already allocated or outside of the pool.
+	ErrUnavailable = errors.New("intervalset: value is not available")
+
+	// ErrInvalidSize is returned when the size of a block is not positive.
+	ErrInvalidSize = errors.New("intervalset: invalid block size")
+)
+
+// FitPolicy defines how an Allocator picks the free range a block is allocated from.
+type FitPolicy int
+
+const (
+	// FirstFit allocates blocks from the lowest free range large enough.
+	FirstFit FitPolicy = iota
+
+	// BestFit allocates blocks from the smallest free range large enough,
+	// the lowest one being picked between ranges of the same size.
+	BestFit
+)
+
+// NewAllocator returns an allocator handing out the integers of the given ranges,
+// all of them being free, allocating blocks according to the given policy.
+// As with Count, a range [l, u) holds the integers from l included to u excluded.
+func NewAllocator[T constraints.Integer](policy FitPolicy, pool ...Interval[T]) *Allocator[T] {
+	return &Allocator[T]{
+		policy: policy,
+		pool:   EmptySet[T]().Add(pool...),
+		free:   EmptySet[T]().Add(pool...),
+	}
+}
+
+// Allocator hands out integers from a pool, such as sequence numbers or ports,
+// and keeps track of the free ones in a set of ranges.
+type Allocator[T constraints.Integer] struct {
+	policy FitPolicy
+	pool   *IntervalSet[T]
+	free   *IntervalSet[T]
+}
+
+// Allocate allocates a single value, picked as a block of size 1 according to the policy.
+// It returns ErrExhausted when there are no free values left.
+func (a *Allocator[T]) Allocate() (T, error) {
+	r, err := a.AllocateN(1)
+	return r.Min(), err
+}
+
+// AllocateN allocates a block of n contiguous values and returns it as a range [l, l+n).
+// It returns ErrExhausted when no free range can hold the block.
+func (a *Allocator[T]) AllocateN(n T) (Range[T], error) {
+	if n <= 0 {
+		return Range[T]{}, ErrInvalidSize
+	}
+
+	var found Interval[T]
+	for _, v := range a.free.intervals {
+		if v.Max()-v.Min() < n {
+			continue
+		}
+		if a.policy == FirstFit {
+			found = v
+			break
+		}
+		if found == nil || v.Max()-v.Min() < found.Max()-found.Min() {
+			found = v
+		}
+	}
+	if found == nil {
+		return Range[T]{}, ErrExhausted
+	}
+
+	r := NewRange(found.Min(), found.Min()+n)
+	a.free.Sub(r)
+
+	return r, nil
+}
+
+// AllocateAt allocates the value v.
+// It returns ErrUnavailable when v is already allocated or outside of the pool.
+func (a *Allocator[T]) AllocateAt(v T) error {
+	if !a.IsFree(v) {
+		return ErrUnavailable
+	}
+	a.free.Sub(NewRange(v, v+1))
+	return nil
+}
+
+// Release frees the values of the ranges, values outside of the pool being ignored.
+func (a *Allocator[T]) Release(ranges ...Interval[T]) {
+	for _, r := range ranges {
+		a.pool.IterBetween(r, func(v Interval[T]) bool {
+			a.free.Add(v)
+			return true
+		})
+	}
+}
+
+// IsFree reports whether the value v is in the pool and not allocated.
+func (a *Allocator[T]) IsFree(v T) bool {
+	i := sort.Search(len(a.free.intervals), func(i int) bool {
+		return a.free.intervals[i].Max() > v
+	})
+	return i < len(a.free.intervals) && a.free.intervals[i].Min() <= v
+}
+
+// Free returns the set of free ranges.
+// The set is owned by the allocator and must not be modified.
+func (a *Allocator[T]) Free() *IntervalSet[T] {
+	return a.free
+}
+
+// Available returns the number of free values.
+func (a *Allocator[T]) Available() T {
+	return Count(a.free)
+}
+
+// LargestFree returns the size of the largest block that can be allocated.
+func (a *Allocator[T]) LargestFree() T {
+	var n T
+	for _, v := range a.free.intervals {
+		n = max(n, v.Max()-v.Min())
+	}
+	return n
+}
+
+// Fragmentation returns the share of the free values that are not part of the largest
+// free range, from 0 when the free values are contiguous up to almost 1 when they are
+// scattered across many small ranges. It returns 0 when there are no free values.
+func (a *Allocator[T]) Fragmentation() float64 {
+	n := a.Available()
+	if n == 0 {
+		return 0
+	}
+	return 1 - float64(a.LargestFree())/float64(n)
+}
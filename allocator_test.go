@@ -0,0 +1,148 @@
+package intervalset
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestAllocator_Allocate(t *testing.T) {
+	a := NewAllocator(FirstFit, NewRange(1, 4))
+
+	for _, expected := range []int{1, 2, 3} {
+		got, err := a.Allocate()
+		if err != nil || got != expected {
+			t.Errorf("expected %d, got %d, %v", expected, got, err)
+		}
+	}
+
+	if _, err := a.Allocate(); !errors.Is(err, ErrExhausted) {
+		t.Errorf("expected ErrExhausted, got %v", err)
+	}
+
+	a.Release(NewRange(2, 3))
+	if got, err := a.Allocate(); err != nil || got != 2 {
+		t.Errorf("expected the released value 2, got %d, %v", got, err)
+	}
+}
+
+func TestAllocator_AllocateN(t *testing.T) {
+	/*------------------------------------------------------
+	|  T  | 0   2   4   6   8   10  12  14  16  18  20     |
+	| F   | |-------|   |---|       |-----------|          |
+	------------------------------------------------------*/
+	free := []Interval[int]{NewRange(0, 4), NewRange(6, 8), NewRange(12, 18)}
+
+	var table = []struct {
+		policy   FitPolicy
+		n        int
+		expected Range[int]
+		err      error
+	}{
+		{FirstFit, 2, NewRange(0, 2), nil},
+		{BestFit, 2, NewRange(6, 8), nil},
+		{FirstFit, 3, NewRange(0, 3), nil},
+		{BestFit, 3, NewRange(0, 3), nil},
+		{FirstFit, 5, NewRange(12, 17), nil},
+		{BestFit, 6, NewRange(12, 18), nil},
+		{FirstFit, 7, Range[int]{}, ErrExhausted},
+		{BestFit, 0, Range[int]{}, ErrInvalidSize},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			a := NewAllocator(tc.policy, free...)
+
+			got, err := a.AllocateN(tc.n)
+			if got != tc.expected || !errors.Is(err, tc.err) {
+				t.Errorf("expected %v, %v, got %v, %v", tc.expected, tc.err, got, err)
+			}
+			if err == nil && !a.Free().Overlaps(got).IsEmpty() {
+				t.Errorf("expected the block to be allocated, got free ranges %v", a.Free().AsSlice())
+			}
+		})
+	}
+}
+
+func TestAllocator_AllocateAt(t *testing.T) {
+	a := NewAllocator(FirstFit, NewRange[uint16](1024, 2048))
+
+	if err := a.AllocateAt(8080); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected ErrUnavailable, got %v", err)
+	}
+	if err := a.AllocateAt(1500); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := a.AllocateAt(1500); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected ErrUnavailable, got %v", err)
+	}
+
+	expected := EmptySet[uint16]().Add(NewRange[uint16](1024, 1500), NewRange[uint16](1501, 2048))
+	if !a.Free().Equal(expected) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, a.Free().AsSlice())
+	}
+	if a.IsFree(1500) || !a.IsFree(1501) || a.IsFree(2048) {
+		t.Errorf("unexpected free values %v", a.Free().AsSlice())
+	}
+}
+
+func TestAllocator_Release(t *testing.T) {
+	a := NewAllocator(FirstFit, NewRange(0, 10))
+	if _, err := a.AllocateN(10); err != nil {
+		t.Fatal(err)
+	}
+
+	// values outside of the pool are ignored and releasing twice is harmless
+	a.Release(NewRange(-5, 2), NewRange(4, 6), NewRange(8, 20), NewRange(4, 6))
+
+	expected := genExpectedRangeSet([]Interval[int]{NewRange(0, 2), NewRange(4, 6), NewRange(8, 10)})
+	if !a.Free().Equal(expected) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, a.Free().AsSlice())
+	}
+	if a.Available() != 6 {
+		t.Errorf("expected 6 free values, got %d", a.Available())
+	}
+}
+
+func TestAllocator_Fragmentation(t *testing.T) {
+	a := NewAllocator(FirstFit, NewRange(0, 10))
+
+	if f := a.Fragmentation(); f != 0 {
+		t.Errorf("expected no fragmentation, got %v", f)
+	}
+
+	for _, v := range []int{2, 5, 7} {
+		if err := a.AllocateAt(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// free values: [0, 2) [3, 5) [6, 7) [8, 10)
+	if a.LargestFree() != 2 {
+		t.Errorf("expected the largest free block to be 2, got %d", a.LargestFree())
+	}
+	if f := a.Fragmentation(); f != 1-2.0/7 {
+		t.Errorf("expected a fragmentation of %v, got %v", 1-2.0/7, f)
+	}
+
+	if _, err := a.AllocateN(7); !errors.Is(err, ErrExhausted) {
+		t.Errorf("expected ErrExhausted, got %v", err)
+	}
+	if _, err := a.AllocateN(a.Available()); !errors.Is(err, ErrExhausted) {
+		t.Errorf("expected ErrExhausted, got %v", err)
+	}
+}
+
+func ExampleAllocator() {
+	a := NewAllocator(BestFit, NewRange(8000, 8010), NewRange(9000, 9002))
+
+	port, _ := a.Allocate()
+	block, _ := a.AllocateN(2)
+	fmt.Println(port, block)
+
+	a.Release(NewRange(port, port+1))
+	fmt.Println(a.Free().AsSlice())
+	// Output:
+	// 9000 {8000 8002}
+	// [{8002 8010} {9000 9002}]
+}
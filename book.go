@@ -0,0 +1,138 @@
+package intervalset
+
+import (
+	"errors"
+	"sort"
+)
+
+var (
+	// ErrConflict is returned when a reservation overlaps existing reservations.
+	ErrConflict = errors.New("intervalset: reservation conflicts with existing reservations")
+
+	// ErrDuplicateID is returned when a reservation ID is already in use.
+	ErrDuplicateID = errors.New("intervalset: duplicate reservation ID")
+)
+
+// OverlapPolicy defines how a Book handles overlapping reservations.
+type OverlapPolicy int
+
+const (
+	// RejectOverlaps rejects reservations overlapping existing reservations.
+	RejectOverlaps OverlapPolicy = iota
+
+	// AllowOverlaps accepts reservations overlapping existing reservations and reports the conflicts.
+	AllowOverlaps
+)
+
+// Reservation is an interval booked under an ID.
+type Reservation[T any] struct {
+	ID       string
+	Interval Interval[T]
+}
+
+// NewBook returns a new empty reservation book handling overlaps according to the given policy.
+func NewBook[T any](policy OverlapPolicy) *Book[T] {
+	return &Book[T]{
+		policy:       policy,
+		reservations: make(map[string]Interval[T]),
+		occupied:     EmptySet[T](),
+	}
+}
+
+// Book keeps track of reservations, unlike IntervalSet which merges the intervals
+// it holds, it keeps the original reservations so they can be cancelled.
+type Book[T any] struct {
+	policy       OverlapPolicy
+	reservations map[string]Interval[T]
+
+	// occupied is the union of the reservations, nil when it needs to be rebuilt.
+	occupied *IntervalSet[T]
+}
+
+// Reserve books q under the given ID and returns the existing reservations overlapping q,
+// reservations merely adjoining q are not considered overlapping. With RejectOverlaps,
+// the reservation is not booked when it conflicts with existing reservations and
+// ErrConflict is returned along with the conflicts.
+// Invalid intervals are rejected with the error returned by Validate.
+func (b *Book[T]) Reserve(id string, q Interval[T]) ([]Reservation[T], error) {
+	if _, ok := b.reservations[id]; ok {
+		return nil, ErrDuplicateID
+	}
+	if err := Validate(q); err != nil {
+		return nil, err
+	}
+
+	conflicts := make([]Reservation[T], 0)
+
+	// only look up the conflicting reservations when q overlaps the occupied intervals
+	if !b.Occupied().Overlaps(q).IsEmpty() {
+		for _, r := range b.Reservations() {
+			if !r.Interval.Intersect(q).IsZero() {
+				conflicts = append(conflicts, r)
+			}
+		}
+	}
+
+	if len(conflicts) > 0 && b.policy == RejectOverlaps {
+		return conflicts, ErrConflict
+	}
+
+	b.reservations[id] = q
+	b.occupied.Add(q)
+
+	return conflicts, nil
+}
+
+// Cancel cancels the reservation with the given ID and reports whether it existed.
+func (b *Book[T]) Cancel(id string) bool {
+	q, ok := b.reservations[id]
+	if !ok {
+		return false
+	}
+
+	delete(b.reservations, id)
+
+	if b.policy == RejectOverlaps {
+		// reservations do not overlap, we can simply free the interval
+		b.Occupied().Sub(q)
+	} else {
+		b.occupied = nil
+	}
+
+	return true
+}
+
+// Get returns the reservation with the given ID and reports whether it exists.
+func (b *Book[T]) Get(id string) (Reservation[T], bool) {
+	q, ok := b.reservations[id]
+	return Reservation[T]{ID: id, Interval: q}, ok
+}
+
+// Reservations returns the reservations ordered by their minimum value.
+func (b *Book[T]) Reservations() []Reservation[T] {
+	r := make([]Reservation[T], 0, len(b.reservations))
+	for id, q := range b.reservations {
+		r = append(r, Reservation[T]{ID: id, Interval: q})
+	}
+
+	sort.Slice(r, func(i, j int) bool {
+		if c := compareMin(r[i].Interval, r[j].Interval.Min()); c != 0 {
+			return c < 0
+		}
+		return r[i].ID < r[j].ID
+	})
+
+	return r
+}
+
+// Occupied returns the set of occupied intervals, i.e. the union of the reservations.
+// The set is owned by the book and must not be modified.
+func (b *Book[T]) Occupied() *IntervalSet[T] {
+	if b.occupied == nil {
+		b.occupied = EmptySet[T]()
+		for _, q := range b.reservations {
+			b.occupied.Add(q)
+		}
+	}
+	return b.occupied
+}
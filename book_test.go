@@ -0,0 +1,127 @@
+package intervalset
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestBook_Reserve(t *testing.T) {
+	b := NewBook[int](RejectOverlaps)
+
+	if _, err := b.Reserve("a", NewRange(1, 3)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := b.Reserve("b", NewRange(3, 5)); err != nil {
+		t.Fatalf("expected an adjoining reservation to be accepted, got %v", err)
+	}
+	if _, err := b.Reserve("a", NewRange(8, 9)); !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("expected %v, got %v", ErrDuplicateID, err)
+	}
+	if _, err := b.Reserve("z", NewRange(9, 8)); !errors.Is(err, ErrInvertedBounds) {
+		t.Errorf("expected %v, got %v", ErrInvertedBounds, err)
+	}
+
+	conflicts, err := b.Reserve("c", NewRange(2, 4))
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("expected %v, got %v", ErrConflict, err)
+	}
+
+	expected := []Reservation[int]{
+		{ID: "a", Interval: NewRange(1, 3)},
+		{ID: "b", Interval: NewRange(3, 5)},
+	}
+	if fmt.Sprint(conflicts) != fmt.Sprint(expected) {
+		t.Errorf("expected conflicts %v, got %v", expected, conflicts)
+	}
+	if _, ok := b.Get("c"); ok {
+		t.Errorf("expected the conflicting reservation to be rejected")
+	}
+
+	occupied := genExpectedRangeSet([]Interval[int]{NewRange(1, 5)})
+	if !b.Occupied().Equal(occupied) {
+		t.Errorf("both sets should be equal, expected %v, got %v", occupied, b.Occupied().AsSlice())
+	}
+}
+
+func TestBook_AllowOverlaps(t *testing.T) {
+	b := NewBook[int](AllowOverlaps)
+
+	b.Reserve("a", NewRange(1, 4))
+	b.Reserve("b", NewRange(6, 8))
+
+	conflicts, err := b.Reserve("c", NewRange(3, 7))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(conflicts) != 2 || conflicts[0].ID != "a" || conflicts[1].ID != "b" {
+		t.Errorf("expected reservations a and b to conflict, got %v", conflicts)
+	}
+
+	var table = []struct {
+		id       string
+		ok       bool
+		occupied *IntervalSet[int]
+	}{
+		{"c", true, genExpectedRangeSet([]Interval[int]{NewRange(1, 4), NewRange(6, 8)})},
+		{"c", false, genExpectedRangeSet([]Interval[int]{NewRange(1, 4), NewRange(6, 8)})},
+		{"a", true, genExpectedRangeSet([]Interval[int]{NewRange(6, 8)})},
+		{"b", true, EmptySet[int]()},
+	}
+
+	b.Reserve("d", NewRange(2, 3))
+	b.Cancel("d")
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			if ok := b.Cancel(tc.id); ok != tc.ok {
+				t.Errorf("expected %t, got %t", tc.ok, ok)
+			}
+			if !b.Occupied().Equal(tc.occupied) {
+				t.Errorf("both sets should be equal, expected %v, got %v", tc.occupied, b.Occupied().AsSlice())
+			}
+		})
+	}
+}
+
+func TestBook_Cancel(t *testing.T) {
+	b := NewBook[int](RejectOverlaps)
+
+	b.Reserve("a", NewRange(1, 3))
+	b.Reserve("b", NewRange(3, 5))
+	b.Reserve("c", NewRange(7, 9))
+
+	if !b.Cancel("b") {
+		t.Errorf("expected reservation b to be cancelled")
+	}
+
+	expected := genExpectedRangeSet([]Interval[int]{NewRange(1, 3), NewRange(7, 9)})
+	if !b.Occupied().Equal(expected) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, b.Occupied().AsSlice())
+	}
+
+	// the freed slot can be booked again
+	if _, err := b.Reserve("d", NewRange(3, 7)); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if r := b.Reservations(); len(r) != 3 || r[1].ID != "d" {
+		t.Errorf("unexpected reservations %v", r)
+	}
+}
+
+func ExampleBook() {
+	b := NewBook[int](RejectOverlaps)
+
+	b.Reserve("alice", NewRange(9, 11))
+	b.Reserve("bob", NewRange(13, 14))
+
+	conflicts, err := b.Reserve("carol", NewRange(10, 13))
+	fmt.Println(conflicts, err)
+
+	b.Cancel("alice")
+	fmt.Println(b.Occupied().AsSlice())
+
+	// Output:
+	// [{alice {9 11}}] intervalset: reservation conflicts with existing reservations
+	// [{13 14}]
+}
@@ -0,0 +1,114 @@
+package intervalset
+
+import (
+	"sort"
+	"time"
+)
+
+// BusinessCalendar measures time over a set of working periods,
+// e.g. to compute deadlines expressed in working hours.
+type BusinessCalendar struct {
+	working *IntervalSet[time.Time]
+}
+
+// NewBusinessCalendar returns a new calendar of the given working periods.
+// The periods are copied, later changes to the set do not affect the calendar.
+func NewBusinessCalendar(working *IntervalSet[time.Time]) *BusinessCalendar {
+	return &BusinessCalendar{
+		working: EmptySet[time.Time]().Add(working.intervals...),
+	}
+}
+
+// AddHolidays removes the given periods from the working periods.
+func (c *BusinessCalendar) AddHolidays(holidays ...Interval[time.Time]) *BusinessCalendar {
+	c.working.Sub(holidays...)
+	return c
+}
+
+// WorkingPeriods returns a new set containing the working periods of the calendar.
+func (c *BusinessCalendar) WorkingPeriods() *IntervalSet[time.Time] {
+	return EmptySet[time.Time]().Add(c.working.intervals...)
+}
+
+// WorkingTime returns the working time between from and to.
+// The result is negative when to is before from.
+func (c *BusinessCalendar) WorkingTime(from, to time.Time) time.Duration {
+	if to.Before(from) {
+		return -c.WorkingTime(to, from)
+	}
+	return Duration(c.working.Overlaps(NewPeriod(from, to)))
+}
+
+// NextWorkingInstant returns the first working instant at or after t.
+// It reports false when there are no working periods after t.
+func (c *BusinessCalendar) NextWorkingInstant(t time.Time) (time.Time, bool) {
+	i := c.indexAfter(t)
+	if i == len(c.working.intervals) {
+		return time.Time{}, false
+	}
+
+	v := c.working.intervals[i]
+	if v.Min().After(t) {
+		return v.Min(), true
+	}
+	return t, true
+}
+
+// AddWorkingTime returns the instant at which d of working time has elapsed since t.
+// A negative duration returns the instant at which d of working time elapsed before t.
+// It reports false when the working periods of the calendar do not hold enough working time.
+func (c *BusinessCalendar) AddWorkingTime(t time.Time, d time.Duration) (time.Time, bool) {
+	if d < 0 {
+		return c.subWorkingTime(t, -d)
+	}
+	if d == 0 {
+		return t, true
+	}
+
+	for _, v := range c.working.intervals[c.indexAfter(t):] {
+		s := v.Min()
+		if t.After(s) {
+			s = t
+		}
+
+		a := v.Max().Sub(s)
+		if d <= a {
+			return s.Add(d), true
+		}
+		d -= a
+	}
+
+	return time.Time{}, false
+}
+
+func (c *BusinessCalendar) subWorkingTime(t time.Time, d time.Duration) (time.Time, bool) {
+	// find the first period starting at or after t,
+	// the ones before might contain working time before t.
+	i := sort.Search(len(c.working.intervals), func(i int) bool {
+		return !c.working.intervals[i].Min().Before(t)
+	})
+
+	for j := i - 1; j >= 0; j-- {
+		v := c.working.intervals[j]
+
+		e := v.Max()
+		if t.Before(e) {
+			e = t
+		}
+
+		a := e.Sub(v.Min())
+		if d <= a {
+			return e.Add(-d), true
+		}
+		d -= a
+	}
+
+	return time.Time{}, false
+}
+
+// indexAfter returns the index of the first working period ending after t.
+func (c *BusinessCalendar) indexAfter(t time.Time) int {
+	return sort.Search(len(c.working.intervals), func(i int) bool {
+		return c.working.intervals[i].Max().After(t)
+	})
+}
@@ -0,0 +1,145 @@
+package intervalset
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// genWorkingDays returns the working periods from 9am to 5pm, Monday to Friday, in December 2023.
+func genWorkingDays() *IntervalSet[time.Time] {
+	s := EmptySet[time.Time]()
+	for d := 1; d <= 31; d++ {
+		day := time.Date(2023, time.December, d, 0, 0, 0, 0, time.UTC)
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+		s.Add(NewPeriod(day.Add(9*time.Hour), day.Add(17*time.Hour)))
+	}
+	return s
+}
+
+func TestBusinessCalendar_AddWorkingTime(t *testing.T) {
+	c := NewBusinessCalendar(genWorkingDays()).
+		AddHolidays(NewPeriod(
+			time.Date(2023, time.December, 25, 0, 0, 0, 0, time.UTC),
+			time.Date(2023, time.December, 27, 0, 0, 0, 0, time.UTC),
+		))
+
+	at := func(d, h, m int) time.Time {
+		return time.Date(2023, time.December, d, h, m, 0, 0, time.UTC)
+	}
+
+	var table = []struct {
+		t  time.Time
+		d  time.Duration
+		e  time.Time
+		ok bool
+	}{
+		{at(4, 10, 0), 4 * time.Hour, at(4, 14, 0), true},
+		{at(4, 7, 0), 4 * time.Hour, at(4, 13, 0), true},
+		{at(1, 15, 0), 4 * time.Hour, at(4, 11, 0), true},    // over the weekend
+		{at(2, 12, 0), 30 * time.Minute, at(4, 9, 30), true}, // during the weekend
+		{at(4, 13, 0), 4 * time.Hour, at(4, 17, 0), true},
+		{at(22, 16, 0), 2 * time.Hour, at(27, 10, 0), true}, // over the holidays
+		{at(4, 14, 0), -4 * time.Hour, at(4, 10, 0), true},
+		{at(4, 11, 0), -4 * time.Hour, at(1, 15, 0), true},
+		{at(4, 11, 0), 0, at(4, 11, 0), true},
+		{at(29, 16, 0), 2 * time.Hour, time.Time{}, false},
+		{at(1, 10, 0), -2 * time.Hour, time.Time{}, false},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			got, ok := c.AddWorkingTime(tc.t, tc.d)
+			if ok != tc.ok || !got.Equal(tc.e) {
+				t.Errorf("expected %s (%t), got %s (%t)", tc.e, tc.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestBusinessCalendar_WorkingTime(t *testing.T) {
+	c := NewBusinessCalendar(genWorkingDays())
+
+	at := func(d, h, m int) time.Time {
+		return time.Date(2023, time.December, d, h, m, 0, 0, time.UTC)
+	}
+
+	var table = []struct {
+		from time.Time
+		to   time.Time
+		e    time.Duration
+	}{
+		{at(4, 10, 0), at(4, 11, 30), 90 * time.Minute},
+		{at(1, 15, 0), at(4, 11, 0), 4 * time.Hour},
+		{at(2, 0, 0), at(3, 0, 0), 0},
+		{at(4, 0, 0), at(11, 0, 0), 40 * time.Hour},
+		{at(4, 11, 0), at(1, 15, 0), -4 * time.Hour},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			if got := c.WorkingTime(tc.from, tc.to); got != tc.e {
+				t.Errorf("expected %s, got %s", tc.e, got)
+			}
+		})
+	}
+}
+
+func TestBusinessCalendar_NextWorkingInstant(t *testing.T) {
+	s := genWorkingDays()
+	c := NewBusinessCalendar(s)
+
+	// the calendar must not be affected by changes to the set
+	s.Sub(NewPeriod(
+		time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	))
+
+	at := func(d, h, m int) time.Time {
+		return time.Date(2023, time.December, d, h, m, 0, 0, time.UTC)
+	}
+
+	var table = []struct {
+		t  time.Time
+		e  time.Time
+		ok bool
+	}{
+		{at(4, 10, 0), at(4, 10, 0), true},
+		{at(4, 9, 0), at(4, 9, 0), true},
+		{at(4, 17, 0), at(5, 9, 0), true},
+		{at(2, 10, 0), at(4, 9, 0), true},
+		{at(29, 17, 0), time.Time{}, false},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			got, ok := c.NextWorkingInstant(tc.t)
+			if ok != tc.ok || !got.Equal(tc.e) {
+				t.Errorf("expected %s (%t), got %s (%t)", tc.e, tc.ok, got, ok)
+			}
+		})
+	}
+}
+
+func ExampleBusinessCalendar_AddWorkingTime() {
+	working := EmptySet[time.Time]()
+	for d := 1; d <= 31; d++ {
+		day := time.Date(2023, time.December, d, 0, 0, 0, 0, time.UTC)
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			working.Add(NewPeriod(day.Add(9*time.Hour), day.Add(17*time.Hour)))
+		}
+	}
+
+	c := NewBusinessCalendar(working)
+
+	// a ticket created on Friday afternoon must be resolved within 4 working hours
+	created := time.Date(2023, time.December, 1, 15, 0, 0, 0, time.UTC)
+	deadline, _ := c.AddWorkingTime(created, 4*time.Hour)
+
+	fmt.Println(deadline.Format(time.RFC1123))
+
+	// Output:
+	// Mon, 04 Dec 2023 11:00:00 UTC
+}
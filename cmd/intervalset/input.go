@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mickaelvieira/intervalset"
+)
+
+// codec describes how values of a given type are parsed, formatted and measured.
+type codec[T any] struct {
+	parse    func(string) (T, error)
+	format   func(T) string
+	compare  func(a, b T) int
+	interval func(l, u T) intervalset.Interval[T]
+	measure  func(*intervalset.IntervalSet[T]) string
+
+	// quoted reports whether the formatted value must be quoted in JSON, nil when it never does.
+	quoted func(T) bool
+}
+
+var integers = codec[int64]{
+	parse: func(s string) (int64, error) {
+		return strconv.ParseInt(s, 10, 64)
+	},
+	format: func(v int64) string {
+		return strconv.FormatInt(v, 10)
+	},
+	compare: compareNumbers[int64],
+	interval: func(l, u int64) intervalset.Interval[int64] {
+		return intervalset.NewRange(l, u)
+	},
+	measure: func(s *intervalset.IntervalSet[int64]) string {
+		return strconv.FormatInt(intervalset.Length(s), 10)
+	},
+}
+
+var floats = codec[float64]{
+	parse: func(s string) (float64, error) {
+		v, err := strconv.ParseFloat(s, 64)
+		if err == nil && math.IsNaN(v) {
+			return v, fmt.Errorf("invalid number %q", s)
+		}
+		return v, err
+	},
+	format: func(v float64) string {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	},
+	compare: compareNumbers[float64],
+	interval: func(l, u float64) intervalset.Interval[float64] {
+		return intervalset.NewRange(l, u)
+	},
+	measure: func(s *intervalset.IntervalSet[float64]) string {
+		return strconv.FormatFloat(intervalset.Length(s), 'g', -1, 64)
+	},
+	// infinities are not valid JSON numbers, they are written as the strings "-Inf" and "+Inf"
+	quoted: func(v float64) bool {
+		return math.IsInf(v, 0)
+	},
+}
+
+var times = codec[time.Time]{
+	parse: func(s string) (time.Time, error) {
+		return time.Parse(time.RFC3339Nano, s)
+	},
+	format: func(v time.Time) string {
+		return v.Format(time.RFC3339Nano)
+	},
+	compare: func(a, b time.Time) int {
+		return a.Compare(b)
+	},
+	interval: func(l, u time.Time) intervalset.Interval[time.Time] {
+		return intervalset.NewPeriod(l, u)
+	},
+	measure: func(s *intervalset.IntervalSet[time.Time]) string {
+		return intervalset.Duration(s).String()
+	},
+	quoted: func(time.Time) bool {
+		return true
+	},
+}
+
+func compareNumbers[T int64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// readSet reads a set from the named file, "-" being the standard input.
+func readSet[T any](c codec[T], name string, stdin io.Reader) (*intervalset.IntervalSet[T], error) {
+	if name == "-" {
+		return decodeSet(c, "stdin", stdin)
+	}
+
+	f, err := os.Open(filepath.Clean(name))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck
+
+	return decodeSet(c, name, f)
+}
+
+// decodeSet reads a set made of one interval per line. Each line is either a CSV record,
+// a JSON object with min and max or start and end fields, or an interval notation such as
+// [1,5] which also covers JSON arrays. Empty lines and lines starting with # are ignored,
+// so is a CSV header on the first line.
+func decodeSet[T any](c codec[T], name string, r io.Reader) (*intervalset.IntervalSet[T], error) {
+	s := intervalset.EmptySet[T]()
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		q, err := parseInterval(c, line)
+		if err != nil {
+			if n == 1 && isHeader(line) {
+				continue
+			}
+			return nil, fmt.Errorf("%s:%d: %w", name, n, err)
+		}
+
+		// empty intervals do not cover anything
+		if c.compare(q.Min(), q.Max()) < 0 {
+			s.Add(q)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return s, nil
+}
+
+// isHeader reports whether the line is a CSV header, i.e. a list of names such as min,max.
+func isHeader(line string) bool {
+	fields := strings.Split(line, ",")
+	if len(fields) < 2 {
+		return false
+	}
+	for _, f := range fields {
+		f = unquote(f)
+		if f == "" || strings.TrimFunc(f, func(r rune) bool {
+			return unicode.IsLetter(r) || r == '_' || r == ' '
+		}) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseInterval parses an interval written in any of the supported formats.
+func parseInterval[T any](c codec[T], s string) (intervalset.Interval[T], error) {
+	var l, u string
+
+	if s == "" {
+		return nil, fmt.Errorf("empty interval")
+	}
+
+	switch s[0] {
+	case '{':
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(s), &fields); err != nil {
+			return nil, fmt.Errorf("invalid JSON object: %w", err)
+		}
+		var ok bool
+		if l, u, ok = jsonBounds(fields, "min", "max"); !ok {
+			if l, u, ok = jsonBounds(fields, "start", "end"); !ok {
+				return nil, fmt.Errorf("expected min and max or start and end fields")
+			}
+		}
+	case '(':
+		return nil, fmt.Errorf("unsupported bracket in %q, use square brackets", s)
+	case '[':
+		if s[len(s)-1] == ')' {
+			return nil, fmt.Errorf("unsupported bracket in %q, use square brackets", s)
+		}
+		if s[len(s)-1] != ']' {
+			return nil, fmt.Errorf("missing closing bracket in %q", s)
+		}
+		var ok bool
+		if l, u, ok = strings.Cut(s[1:len(s)-1], ","); !ok {
+			return nil, fmt.Errorf("expected two bounds in %q", s)
+		}
+	default:
+		var ok bool
+		if l, u, ok = strings.Cut(s, ","); !ok {
+			return nil, fmt.Errorf("expected two comma separated bounds in %q", s)
+		}
+	}
+
+	lo, err := c.parse(unquote(l))
+	if err != nil {
+		return nil, err
+	}
+	hi, err := c.parse(unquote(u))
+	if err != nil {
+		return nil, err
+	}
+	if c.compare(lo, hi) > 0 {
+		return nil, fmt.Errorf("lower bound %s greater than upper bound %s", c.format(lo), c.format(hi))
+	}
+
+	return c.interval(lo, hi), nil
+}
+
+func jsonBounds(fields map[string]json.RawMessage, lkey, ukey string) (string, string, bool) {
+	l, ok1 := fields[lkey]
+	u, ok2 := fields[ukey]
+	return string(l), string(u), ok1 && ok2
+}
+
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,343 @@
+// Command intervalset runs set operations on lists of intervals read from files or the standard input.
+//
+// Usage:
+//
+//	intervalset [flags] command [arguments] [files]
+//
+// The commands are:
+//
+//	union [files]               union of the sets
+//	intersect [files]           intersection of the sets
+//	diff file [files]           first set minus the other sets
+//	complement window [file]    portions of the window not covered by the set
+//	gaps [file]                 gaps between the intervals of the set
+//	measure [files]             total length, or duration, covered by the sets
+//	contains value [file]       whether the set contains a value or an interval
+//	render [files]              timeline of the sets, labelled with the file names
+//
+// Each input holds one interval per line, either as a CSV record (1,5), a JSON object
+// ({"min":1,"max":5} or {"start":...,"end":...}) or an interval notation ([1,5] or ["a","b"]).
+// As in the library, an interval covers the values from its lower bound included to its upper bound
+// excluded: [1,5] and [5,8] are adjoining and the value 5 is contained in the latter only. The notation
+// always uses square brackets and rejects parentheses. Infinite floats are written as the strings
+// "-Inf" and "+Inf" in JSON since they are not valid JSON numbers.
+// Without files, the set is read from the standard input, which can also be named with "-".
+//
+// The flags are:
+//
+//	-type string     type of the values: int, float or time (RFC 3339), defaults to int
+//	-output string   output format: notation, csv or jsonl, defaults to notation
+//	-width int       number of columns of the rendered timeline, defaults to 60
+//
+// The contains command exits with the status 1 when the value is not contained in the set.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mickaelvieira/intervalset"
+)
+
+const usage = `usage: intervalset [flags] command [arguments] [files]
+
+commands:
+  union [files]               union of the sets
+  intersect [files]           intersection of the sets
+  diff file [files]           first set minus the other sets
+  complement window [file]    portions of the window not covered by the set
+  gaps [file]                 gaps between the intervals of the set
+  measure [files]             total length, or duration, covered by the sets
+  contains value [file]       whether the set contains a value or an interval
+  render [files]              timeline of the sets, labelled with the file names
+
+flags:
+`
+
+// errNotContained signals that the contains command did not find the value.
+var errNotContained = errors.New("not contained")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command line and returns the exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("intervalset", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	kind := flags.String("type", "int", "type of the values: int, float or time (RFC 3339)")
+	output := flags.String("output", "notation", "output format: notation, csv or jsonl")
+	width := flags.Int("width", 60, "number of columns of the rendered timeline")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	var err error
+	switch *kind {
+	case "int":
+		err = execute(integers, options{*output, *width}, flags.Args(), stdin, stdout)
+	case "float":
+		err = execute(floats, options{*output, *width}, flags.Args(), stdin, stdout)
+	case "time":
+		err = execute(times, options{*output, *width}, flags.Args(), stdin, stdout)
+	default:
+		err = fmt.Errorf("unknown type %q", *kind)
+	}
+
+	switch {
+	case errors.Is(err, errNotContained):
+		return 1
+	case err != nil:
+		fmt.Fprintf(stderr, "intervalset: %s\n", err)
+		return 2
+	}
+	return 0
+}
+
+// options holds the flags affecting the output of the commands.
+type options struct {
+	output string
+	width  int
+}
+
+// execute runs the command with the values of the codec type.
+func execute[T any](c codec[T], opts options, args []string, stdin io.Reader, stdout io.Writer) error {
+	write, ok := writers[opts.output]
+	if !ok {
+		return fmt.Errorf("unknown output format %q", opts.output)
+	}
+
+	cmd, args := args[0], args[1:]
+
+	switch cmd {
+	case "union":
+		sets, err := readSets(c, args, stdin)
+		if err != nil {
+			return err
+		}
+		return writeSet(stdout, c, write, intervalset.Union(sets...))
+
+	case "intersect":
+		sets, err := readSets(c, args, stdin)
+		if err != nil {
+			return err
+		}
+		s := sets[0]
+		if len(sets) > 1 {
+			s = intervalset.Intersection(sets...)
+		}
+		return writeSet(stdout, c, write, s)
+
+	case "diff":
+		if len(args) == 0 {
+			return fmt.Errorf("diff expects at least one file")
+		}
+		sets, err := readSets(c, args, stdin)
+		if err != nil {
+			return err
+		}
+		return writeSet(stdout, c, write, sets[0].Difference(intervalset.Union(sets[1:]...)))
+
+	case "complement":
+		if len(args) == 0 {
+			return fmt.Errorf("complement expects a window")
+		}
+		window, err := parseInterval(c, args[0])
+		if err != nil {
+			return fmt.Errorf("invalid window: %w", err)
+		}
+		s, err := readOne(c, args[1:], stdin)
+		if err != nil {
+			return err
+		}
+		return writeSet(stdout, c, write, s.Complement(window))
+
+	case "gaps":
+		s, err := readOne(c, args, stdin)
+		if err != nil {
+			return err
+		}
+		intervals := s.AsSlice()
+		if len(intervals) == 0 {
+			return nil
+		}
+		window := c.interval(intervals[0].Min(), intervals[len(intervals)-1].Max())
+		return writeSet(stdout, c, write, s.Complement(window))
+
+	case "measure":
+		sets, err := readSets(c, args, stdin)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(stdout, c.measure(intervalset.Union(sets...)))
+		return err
+
+	case "contains":
+		if len(args) == 0 {
+			return fmt.Errorf("contains expects a value or an interval")
+		}
+		s, err := readOne(c, args[1:], stdin)
+		if err != nil {
+			return err
+		}
+		ok, err := contains(c, s, args[0])
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(stdout, ok); err != nil {
+			return err
+		}
+		if !ok {
+			return errNotContained
+		}
+		return nil
+
+	case "render":
+		sets, err := readSets(c, args, stdin)
+		if err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			args = []string{"-"}
+		}
+		tracks := make([]intervalset.Track[T], 0, len(sets))
+		for i, s := range sets {
+			label := filepath.Base(args[i])
+			if args[i] == "-" {
+				label = "stdin"
+			}
+			tracks = append(tracks, intervalset.Track[T]{Label: label, Set: s})
+		}
+		_, err = fmt.Fprint(stdout, intervalset.Render(intervalset.RenderOptions[T]{Width: opts.width}, tracks...))
+		return err
+	}
+
+	return fmt.Errorf("unknown command %q", cmd)
+}
+
+// contains reports whether the set contains the value or the interval.
+// Intervals exclude their upper bound, a value v is therefore contained when an interval
+// of the set starts before or at v and ends after it, e.g. the set [1,5] contains 4 and
+// [4,5] but not 5. Empty intervals, such as [5,5], are rejected since they cover nothing.
+func contains[T any](c codec[T], s *intervalset.IntervalSet[T], arg string) (bool, error) {
+	if strings.ContainsAny(arg, ",[({") {
+		q, err := parseInterval(c, arg)
+		if err != nil {
+			return false, err
+		}
+		if c.compare(q.Min(), q.Max()) == 0 {
+			return false, fmt.Errorf("empty interval %s", arg)
+		}
+		return containsInterval(c, s, q.Min(), q.Max()), nil
+	}
+
+	v, err := c.parse(arg)
+	if err != nil {
+		return false, err
+	}
+
+	ok := false
+	s.Iter(func(q intervalset.Interval[T]) bool {
+		ok = c.compare(q.Min(), v) <= 0 && c.compare(v, q.Max()) < 0
+		return !ok && c.compare(q.Max(), v) <= 0
+	})
+	return ok, nil
+}
+
+// containsInterval reports whether an interval of the set contains the interval [lo,hi].
+func containsInterval[T any](c codec[T], s *intervalset.IntervalSet[T], lo, hi T) bool {
+	ok := false
+	s.Iter(func(q intervalset.Interval[T]) bool {
+		ok = c.compare(q.Min(), lo) <= 0 && c.compare(hi, q.Max()) <= 0
+		return !ok && c.compare(q.Max(), lo) < 0
+	})
+	return ok
+}
+
+// readSets reads a set from each of the named files or from the standard input when there are none.
+func readSets[T any](c codec[T], names []string, stdin io.Reader) ([]*intervalset.IntervalSet[T], error) {
+	if len(names) == 0 {
+		names = []string{"-"}
+	}
+
+	sets := make([]*intervalset.IntervalSet[T], 0, len(names))
+	for _, name := range names {
+		s, err := readSet(c, name, stdin)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, s)
+	}
+	return sets, nil
+}
+
+// readOne reads a set from the named file or from the standard input when there is none.
+func readOne[T any](c codec[T], names []string, stdin io.Reader) (*intervalset.IntervalSet[T], error) {
+	if len(names) > 1 {
+		return nil, fmt.Errorf("expected a single file, got %d", len(names))
+	}
+	sets, err := readSets(c, names, stdin)
+	if err != nil {
+		return nil, err
+	}
+	return sets[0], nil
+}
+
+// bound is a formatted bound of an interval.
+type bound struct {
+	value string
+
+	// quoted reports whether the value must be quoted in JSON.
+	quoted bool
+}
+
+func (b bound) json() string {
+	if b.quoted {
+		return strconv.Quote(b.value)
+	}
+	return b.value
+}
+
+// writers format an interval whose bounds are already formatted.
+var writers = map[string]func(w io.Writer, l, u bound) error{
+	"notation": func(w io.Writer, l, u bound) error {
+		_, err := fmt.Fprintf(w, "[%s,%s]\n", l.value, u.value)
+		return err
+	},
+	"csv": func(w io.Writer, l, u bound) error {
+		_, err := fmt.Fprintf(w, "%s,%s\n", l.value, u.value)
+		return err
+	},
+	"jsonl": func(w io.Writer, l, u bound) error {
+		_, err := fmt.Fprintf(w, "{\"min\":%s,\"max\":%s}\n", l.json(), u.json())
+		return err
+	},
+}
+
+func writeSet[T any](w io.Writer, c codec[T], write func(io.Writer, bound, bound) error, s *intervalset.IntervalSet[T]) error {
+	format := func(v T) bound {
+		return bound{value: c.format(v), quoted: c.quoted != nil && c.quoted(v)}
+	}
+	for _, q := range s.AsSlice() {
+		if err := write(w, format(q.Min()), format(q.Max())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestRun(t *testing.T) {
+	a := writeFile(t, "a.csv", "min,max\n1,5\n8,10\n")
+	b := writeFile(t, "b.jsonl", "{\"min\":4,\"max\":9}\n\n# comment\n{\"start\":12,\"end\":14}\n")
+	c := writeFile(t, "c.txt", "[2,3]\n[6,7]\n")
+
+	var table = []struct {
+		args     []string
+		stdin    string
+		status   int
+		expected string
+	}{
+		{[]string{"union", a, b}, "", 0, "[1,10]\n[12,14]\n"},
+		{[]string{"intersect", a, b}, "", 0, "[4,5]\n[8,9]\n"},
+		{[]string{"intersect", a}, "", 0, "[1,5]\n[8,10]\n"},
+		{[]string{"diff", a, b, c}, "", 0, "[1,2]\n[3,4]\n[9,10]\n"},
+		{[]string{"complement", "[0,20]", a}, "", 0, "[0,1]\n[5,8]\n[10,20]\n"},
+		{[]string{"gaps", b}, "", 0, "[9,12]\n"},
+		{[]string{"measure", a, b}, "", 0, "11\n"},
+		{[]string{"contains", "4", a}, "", 0, "true\n"},
+		{[]string{"contains", "1", a}, "", 0, "true\n"},
+		{[]string{"contains", "5", a}, "", 1, "false\n"},
+		{[]string{"contains", "6", a}, "", 1, "false\n"},
+		{[]string{"contains", "8", a}, "", 0, "true\n"},
+		{[]string{"contains", "[2,4]", a}, "", 0, "true\n"},
+		{[]string{"contains", "[4,5]", a}, "", 0, "true\n"},
+		{[]string{"contains", "[4,9]", a}, "", 1, "false\n"},
+		{[]string{"-output", "csv", "union", "-", c}, "3,6\n", 0, "2,7\n"},
+		{[]string{"-output", "jsonl", "union"}, "1,2\n", 0, "{\"min\":1,\"max\":2}\n"},
+		{[]string{"-type", "float", "measure"}, "0.5,1.75\n", 0, "1.25\n"},
+		{
+			[]string{"-type", "float", "-output", "jsonl", "complement", "[-inf,inf]"},
+			"1,2.5\n",
+			0,
+			"{\"min\":\"-Inf\",\"max\":1}\n{\"min\":2.5,\"max\":\"+Inf\"}\n",
+		},
+		{[]string{"-type", "float", "union"}, "{\"min\":\"-Inf\",\"max\":1}\n", 0, "[-Inf,1]\n"},
+		{
+			[]string{"-type", "time", "gaps"},
+			"[\"2024-01-01T09:00:00Z\",\"2024-01-01T10:00:00Z\"]\n2024-01-01T11:30:00Z,2024-01-01T12:00:00Z\n",
+			0,
+			"[2024-01-01T10:00:00Z,2024-01-01T11:30:00Z]\n",
+		},
+		{
+			[]string{"-type", "time", "-output", "jsonl", "union"},
+			"{\"start\":\"2024-01-01T09:00:00+02:00\",\"end\":\"2024-01-01T10:00:00+02:00\"}\n",
+			0,
+			"{\"min\":\"2024-01-01T09:00:00+02:00\",\"max\":\"2024-01-01T10:00:00+02:00\"}\n",
+		},
+		{[]string{"-type", "time", "measure"}, "2024-01-01T09:00:00Z,2024-01-01T10:30:00Z\n", 0, "1h30m0s\n"},
+		{
+			[]string{"-width", "14", "render", a, "-"},
+			"3,7\n",
+			0,
+			"      1  3   6  8 10\n" +
+				"      +--+---+--+--+\n" +
+				"a.csv |-----|   |--|\n" +
+				"stdin    |-----|\n",
+		},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			status := run(tc.args, strings.NewReader(tc.stdin), &stdout, &stderr)
+
+			if status != tc.status {
+				t.Errorf("expected status %d, got %d: %s", tc.status, status, stderr.String())
+			}
+			if stdout.String() != tc.expected {
+				t.Errorf("expected output %q, got %q", tc.expected, stdout.String())
+			}
+		})
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	var table = []struct {
+		args  []string
+		stdin string
+		err   string
+	}{
+		{[]string{}, "", "usage"},
+		{[]string{"-type", "bool", "union"}, "", "unknown type"},
+		{[]string{"-output", "xml", "union"}, "", "unknown output format"},
+		{[]string{"split"}, "", "unknown command"},
+		{[]string{"union"}, "1,5\n5,1\n", "stdin:2: lower bound 5 greater than upper bound 1"},
+		{[]string{"union"}, "1,5\n[6,7\n", "stdin:2: missing closing bracket"},
+		{[]string{"union"}, "1,5\n{\"min\":1}\n", "stdin:2: expected min and max or start and end fields"},
+		{[]string{"union"}, "1;5\n", "stdin:1:"},
+		{[]string{"union", "does-not-exist"}, "", "does-not-exist"},
+		{[]string{"complement"}, "", "complement expects a window"},
+		{[]string{"complement", "[0]"}, "", "invalid window"},
+		{[]string{"complement", ""}, "", "empty interval"},
+		{[]string{"contains", ""}, "", "invalid syntax"},
+		{[]string{"union"}, "[6,7)\n", "stdin:1: unsupported bracket"},
+		{[]string{"union"}, "(1,5)\n", "stdin:1: unsupported bracket"},
+		{[]string{"contains", "(1,5]", "-"}, "", "unsupported bracket"},
+		{[]string{"contains", "[5,5]", "-"}, "", "empty interval [5,5]"},
+		{[]string{"gaps", "-", "-"}, "", "expected a single file"},
+		{[]string{"-type", "time", "union"}, "2024-01-01,2024-01-02\n", "stdin:1:"},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			if status := run(tc.args, strings.NewReader(tc.stdin), &stdout, &stderr); status != 2 {
+				t.Errorf("expected status 2, got %d", status)
+			}
+			if !strings.Contains(stderr.String(), tc.err) {
+				t.Errorf("expected the error to contain %q, got %q", tc.err, stderr.String())
+			}
+		})
+	}
+}
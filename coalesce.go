@@ -0,0 +1,111 @@
+package intervalset
+
+import (
+	"reflect"
+	"sort"
+	"time"
+)
+
+// WithMaxGap makes Add merge the intervals separated by a gap lower than or equal to gap,
+// a number for ranges or a time.Duration for periods.
+// Subtracting intervals may still leave smaller gaps.
+func WithMaxGap[G Number](gap G) SetOption {
+	return func(c *setConfig) {
+		c.maxGap = float64(gap)
+	}
+}
+
+// Coalesce returns a new set where the intervals of s separated by a gap lower than or equal
+// to maxGap, a number for ranges or a time.Duration for periods, are merged.
+// The new set has the options of s.
+func Coalesce[T any, G Number](s *IntervalSet[T], maxGap G) *IntervalSet[T] {
+	c := emptySetOf[T](s.config())
+	for i := 0; i < len(s.intervals); {
+		j := i
+		for j+1 < len(s.intervals) && distance(s.intervals[j].Max(), s.intervals[j+1].Min()) <= float64(maxGap) {
+			j++
+		}
+
+		q := s.intervals[i]
+		if j > i {
+			if v, ok := newInterval(q.Min(), s.intervals[j].Max()); ok {
+				q = v
+			} else {
+				// the intervals cannot be merged, they are kept as they are
+				j = i
+			}
+		}
+
+		c.intervals = append(c.intervals, q)
+		i = j + 1
+	}
+	return c
+}
+
+// DropShorterThan returns a new set without the intervals of s shorter than minLen,
+// a number for ranges or a time.Duration for periods. The new set has the options of s.
+func DropShorterThan[T any, G Number](s *IntervalSet[T], minLen G) *IntervalSet[T] {
+	c := emptySetOf[T](s.config())
+	for _, q := range s.intervals {
+		if distance(q.Min(), q.Max()) >= float64(minLen) {
+			c.intervals = append(c.intervals, q)
+		}
+	}
+	return c
+}
+
+// bridge returns q extended to the intervals of the set separated from q by a gap
+// lower than or equal to the maximum gap of the set.
+func (p *IntervalSet[T]) bridge(q Interval[T]) Interval[T] {
+	if p.maxGap <= 0 {
+		return q
+	}
+
+	l, u := q.Min(), q.Max()
+
+	i := sort.Search(len(p.intervals), func(i int) bool {
+		return !p.intervals[i].Before(q)
+	})
+	if i > 0 && distance(p.intervals[i-1].Max(), l) <= p.maxGap {
+		l = p.intervals[i-1].Min()
+	}
+
+	j := sort.Search(len(p.intervals), func(j int) bool {
+		return p.intervals[j].After(q)
+	})
+	if j < len(p.intervals) && distance(u, p.intervals[j].Min()) <= p.maxGap {
+		u = p.intervals[j].Max()
+	}
+
+	if isEqual(l, q.Min()) && isEqual(u, q.Max()) {
+		return q
+	}
+	if v, ok := newInterval(l, u); ok {
+		return v
+	}
+	return q
+}
+
+// distance returns b - a, expressed in nanoseconds for times.
+func distance[T any](a, b T) float64 {
+	if t, ok := any(b).(time.Time); ok {
+		return float64(t.Sub(any(a).(time.Time)))
+	}
+	x, y := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch x.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// the difference overflows when its sign does not match the order of the values
+		if d := y.Int() - x.Int(); (d < 0) == (y.Int() < x.Int()) {
+			return float64(d)
+		}
+		return float64(y.Int()) - float64(x.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if y.Uint() < x.Uint() {
+			return -float64(x.Uint() - y.Uint())
+		}
+		return float64(y.Uint() - x.Uint())
+	case reflect.Float32, reflect.Float64:
+		return y.Float() - x.Float()
+	}
+	return 0
+}
@@ -0,0 +1,142 @@
+package intervalset
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCoalesce(t *testing.T) {
+	/*----------------------------------------------
+	|  T  | 1   2   3   4   5   6   7   8   9   10 |
+	| S   | |---|   |---|       |---|   |-|        |
+	----------------------------------------------*/
+	s := EmptySet[int]().Add(NewRange(1, 2), NewRange(3, 4), NewRange(6, 7), NewRange(8, 9))
+
+	var table = []struct {
+		gap      int
+		expected *IntervalSet[int]
+	}{
+		{0, genExpectedRangeSet([]Interval[int]{NewRange(1, 2), NewRange(3, 4), NewRange(6, 7), NewRange(8, 9)})},
+		{1, genExpectedRangeSet([]Interval[int]{NewRange(1, 4), NewRange(6, 9)})},
+		{2, genExpectedRangeSet([]Interval[int]{NewRange(1, 9)})},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			got := Coalesce(s, tc.gap)
+			if !got.Equal(tc.expected) {
+				t.Errorf("both sets should be equal, expected %v, got %v", tc.expected, got.AsSlice())
+			}
+		})
+	}
+
+	if len(s.AsSlice()) != 4 {
+		t.Errorf("expected the set to be left untouched, got %v", s.AsSlice())
+	}
+}
+
+func TestCoalesce_Period(t *testing.T) {
+	s0 := time.Date(2023, time.December, 1, 9, 0, 0, 0, time.UTC)
+
+	// sessions separated by a few seconds
+	s := EmptySet[time.Time]().Add(
+		NewPeriod(s0, s0.Add(10*time.Minute)),
+		NewPeriod(s0.Add(10*time.Minute+5*time.Second), s0.Add(20*time.Minute)),
+		NewPeriod(s0.Add(20*time.Minute+30*time.Second), s0.Add(25*time.Minute)),
+		NewPeriod(s0.Add(time.Hour), s0.Add(time.Hour+2*time.Second)),
+	)
+
+	got := Coalesce(s, 10*time.Second)
+	expected := genExpectedPeriodSet([]Interval[time.Time]{
+		NewPeriod(s0, s0.Add(20*time.Minute)),
+		NewPeriod(s0.Add(20*time.Minute+30*time.Second), s0.Add(25*time.Minute)),
+		NewPeriod(s0.Add(time.Hour), s0.Add(time.Hour+2*time.Second)),
+	})
+	if !got.Equal(expected) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, got.AsSlice())
+	}
+
+	got = DropShorterThan(got, time.Minute)
+	expected = genExpectedPeriodSet([]Interval[time.Time]{
+		NewPeriod(s0, s0.Add(20*time.Minute)),
+		NewPeriod(s0.Add(20*time.Minute+30*time.Second), s0.Add(25*time.Minute)),
+	})
+	if !got.Equal(expected) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, got.AsSlice())
+	}
+}
+
+func TestDropShorterThan(t *testing.T) {
+	s := EmptySet[float64]().Add(NewRange(0, 0.5), NewRange(1, 3.0), NewRange(4, 4.25))
+
+	got := DropShorterThan(s, 0.5)
+	expected := genExpectedFloatSet([]Interval[float64]{NewRange(0, 0.5), NewRange(1, 3.0)})
+	if !got.Equal(expected) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, got.AsSlice())
+	}
+}
+
+func TestIntervalSet_WithMaxGap(t *testing.T) {
+	var table = []struct {
+		add      []Interval[int]
+		sub      []Interval[int]
+		expected *IntervalSet[int]
+	}{
+		{
+			[]Interval[int]{NewRange(1, 2), NewRange(3, 4), NewRange(7, 8)},
+			nil,
+			genExpectedRangeSet([]Interval[int]{NewRange(1, 4), NewRange(7, 8)}),
+		},
+		{
+			// an interval bridging both sides
+			[]Interval[int]{NewRange(1, 2), NewRange(7, 8), NewRange(3, 6)},
+			nil,
+			genExpectedRangeSet([]Interval[int]{NewRange(1, 8)}),
+		},
+		{
+			// subtracting may leave smaller gaps
+			[]Interval[int]{NewRange(1, 8)},
+			[]Interval[int]{NewRange(4, 5)},
+			genExpectedRangeSet([]Interval[int]{NewRange(1, 4), NewRange(5, 8)}),
+		},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			s := EmptySet[int](WithMaxGap(1)).Add(tc.add...).Sub(tc.sub...)
+			if !s.Equal(tc.expected) {
+				t.Errorf("both sets should be equal, expected %v, got %v", tc.expected, s.AsSlice())
+			}
+		})
+	}
+
+	s0 := time.Date(2023, time.December, 1, 9, 0, 0, 0, time.UTC)
+	p := EmptySet[time.Time](WithMaxGap(5 * time.Second)).Add(NewPeriod(s0, s0.Add(time.Minute)))
+
+	// the bridged gap is reported as newly covered
+	p.OnChange(func(a, r *IntervalSet[time.Time]) {
+		if d := Duration(a); d != time.Minute {
+			t.Errorf("expected a minute to be covered, got %s", d)
+		}
+	})
+	if _, ok := p.TryAdd(NewPeriod(s0.Add(time.Minute+5*time.Second), s0.Add(2*time.Minute))); !ok {
+		t.Errorf("expected the period to be added")
+	}
+
+	expected := genExpectedPeriodSet([]Interval[time.Time]{NewPeriod(s0, s0.Add(2*time.Minute))})
+	if !p.Equal(expected) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, p.AsSlice())
+	}
+}
+
+func ExampleCoalesce() {
+	s := EmptySet[int]().Add(NewRange(0, 10), NewRange(12, 20), NewRange(30, 31))
+
+	fmt.Println(Coalesce(s, 5).AsSlice())
+	fmt.Println(DropShorterThan(s, 5).AsSlice())
+
+	// Output:
+	// [{0 20} {30 31}]
+	// [{0 10} {12 20}]
+}
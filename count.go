@@ -0,0 +1,135 @@
+package intervalset
+
+import (
+	"iter"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// The functions below see the ranges of integer sets as sets of integers, a range [l, u)
+// covering the integers from l included to u excluded, as canonicalized by Range.Scan.
+// Empty ranges do not cover any integer.
+
+// Count returns the number of integers covered by the set.
+func Count[T constraints.Integer](s *IntervalSet[T]) T {
+	var n T
+	for _, v := range s.intervals {
+		n += v.Max() - v.Min()
+	}
+	return n
+}
+
+// Values returns an iterator over the integers covered by the set in ascending order.
+func Values[T constraints.Integer](s *IntervalSet[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.intervals {
+			for i := v.Min(); i < v.Max(); i++ {
+				if !yield(i) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Nth returns the k-th integer covered by the set, counting from 0, and reports whether
+// it exists, i.e. whether k is between 0 and Count(s) excluded. It walks the ranges of the
+// set, a Counter answers repeated lookups in logarithmic time.
+func Nth[T constraints.Integer](s *IntervalSet[T], k T) (T, bool) {
+	var zero T
+	if k < 0 {
+		return zero, false
+	}
+	for _, v := range s.intervals {
+		n := v.Max() - v.Min()
+		if k < n {
+			return v.Min() + k, true
+		}
+		k -= n
+	}
+	return zero, false
+}
+
+// Rank returns the number of integers covered by the set which are lower than v,
+// so that Nth(s, Rank(s, v)) returns v when v is covered by the set. It walks the
+// ranges of the set, a Counter answers repeated lookups in logarithmic time.
+func Rank[T constraints.Integer](s *IntervalSet[T], v T) T {
+	var r T
+	for _, q := range s.intervals {
+		if q.Min() >= v {
+			break
+		}
+		r += min(q.Max(), v) - q.Min()
+	}
+	return r
+}
+
+// NewCounter returns a counter of the integers covered by the set at the time of the call.
+// The counter is not updated when the set is modified afterwards.
+func NewCounter[T constraints.Integer](s *IntervalSet[T]) *Counter[T] {
+	c := &Counter[T]{
+		intervals: append(make([]Interval[T], 0, len(s.intervals)), s.intervals...),
+		counts:    make([]T, len(s.intervals)),
+	}
+
+	var n T
+	for i, v := range c.intervals {
+		n += v.Max() - v.Min()
+		c.counts[i] = n
+	}
+	return c
+}
+
+// Counter is an index of the integers covered by a set, built once to answer
+// Nth and Rank lookups with a binary search over the cumulative counts of the ranges.
+type Counter[T constraints.Integer] struct {
+	intervals []Interval[T]
+
+	// counts[i] is the number of integers covered by the range i and the ranges before it.
+	counts []T
+}
+
+// Count returns the number of integers covered by the set.
+func (c *Counter[T]) Count() T {
+	if len(c.counts) == 0 {
+		return 0
+	}
+	return c.counts[len(c.counts)-1]
+}
+
+// Nth returns the k-th integer covered by the set, counting from 0, and reports whether it exists.
+func (c *Counter[T]) Nth(k T) (T, bool) {
+	var zero T
+	if k < 0 {
+		return zero, false
+	}
+
+	// the k-th integer lies in the first range whose cumulative count is greater than k
+	i := sort.Search(len(c.counts), func(i int) bool {
+		return c.counts[i] > k
+	})
+	if i == len(c.counts) {
+		return zero, false
+	}
+
+	v := c.intervals[i]
+	return v.Min() + k - (c.counts[i] - (v.Max() - v.Min())), true
+}
+
+// Rank returns the number of integers covered by the set which are lower than v.
+func (c *Counter[T]) Rank(v T) T {
+	// index of the first range that ends after v
+	i := sort.Search(len(c.intervals), func(i int) bool {
+		return c.intervals[i].Max() > v
+	})
+
+	var r T
+	if i > 0 {
+		r = c.counts[i-1]
+	}
+	if i < len(c.intervals) && c.intervals[i].Min() < v {
+		r += v - c.intervals[i].Min()
+	}
+	return r
+}
@@ -0,0 +1,178 @@
+package intervalset
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	var table = []struct {
+		set      *IntervalSet[int]
+		expected int
+	}{
+		{EmptySet[int](), 0},
+		{EmptySet[int]().Add(NewRange(1, 4)), 3},
+		{EmptySet[int]().Add(NewRange(1, 4), NewRange(6, 7), NewRange(-3, -1)), 6},
+		{EmptySet[int]().Add(NewRange(1, 4), NewRange(4, 7)), 6},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			if got := Count(tc.set); got != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestValues(t *testing.T) {
+	s := EmptySet[uint16]().Add(NewRange[uint16](1, 4), NewRange[uint16](6, 7), NewRange[uint16](9, 11))
+
+	got := slices.Collect(Values(s))
+	expected := []uint16{1, 2, 3, 6, 9, 10}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	// stops when the consumer does
+	got = got[:0]
+	for v := range Values(s) {
+		if v > 6 {
+			break
+		}
+		got = append(got, v)
+	}
+	expected = []uint16{1, 2, 3, 6}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestNthAndRank(t *testing.T) {
+	/*------------------------------------------------
+	|  T  | 1   2   3   4   5   6   7   8   9   10  11 |
+	| S   | |-----------|       |---|       |-------| |
+	------------------------------------------------*/
+	s := EmptySet[int]().Add(NewRange(1, 4), NewRange(6, 7), NewRange(9, 11))
+
+	c := NewCounter(s)
+
+	// every covered value is found back from its rank
+	for k, v := range slices.Collect(Values(s)) {
+		if got, ok := Nth(s, k); !ok || got != v {
+			t.Errorf("expected Nth(%d) to be %d, got %d, %t", k, v, got, ok)
+		}
+		if got, ok := c.Nth(k); !ok || got != v {
+			t.Errorf("expected Counter.Nth(%d) to be %d, got %d, %t", k, v, got, ok)
+		}
+		if got := Rank(s, v); got != k {
+			t.Errorf("expected Rank(%d) to be %d, got %d", v, k, got)
+		}
+		if got := c.Rank(v); got != k {
+			t.Errorf("expected Counter.Rank(%d) to be %d, got %d", v, k, got)
+		}
+	}
+
+	var table = []struct {
+		k        int
+		expected int
+		ok       bool
+	}{
+		{-1, 0, false},
+		{0, 1, true},
+		{3, 6, true},
+		{5, 10, true},
+		{6, 0, false},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("nth test case %d", i), func(t *testing.T) {
+			got, ok := Nth(s, tc.k)
+			if got != tc.expected || ok != tc.ok {
+				t.Errorf("expected %d, %t, got %d, %t", tc.expected, tc.ok, got, ok)
+			}
+			got, ok = c.Nth(tc.k)
+			if got != tc.expected || ok != tc.ok {
+				t.Errorf("expected %d, %t from the counter, got %d, %t", tc.expected, tc.ok, got, ok)
+			}
+		})
+	}
+
+	var ranks = []struct {
+		v        int
+		expected int
+	}{
+		{-5, 0},
+		{1, 0},
+		{4, 3},
+		{5, 3},
+		{7, 4},
+		{8, 4},
+		{11, 6},
+		{100, 6},
+	}
+
+	for i, tc := range ranks {
+		t.Run(fmt.Sprintf("rank test case %d", i), func(t *testing.T) {
+			if got := Rank(s, tc.v); got != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, got)
+			}
+			if got := c.Rank(tc.v); got != tc.expected {
+				t.Errorf("expected %d from the counter, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCounter(t *testing.T) {
+	s := EmptySet[int]().Add(NewRange(1, 4), NewRange(6, 7))
+	c := NewCounter(s)
+
+	if c.Count() != Count(s) {
+		t.Errorf("expected %d, got %d", Count(s), c.Count())
+	}
+
+	// the counter is not updated with the set
+	s.Add(NewRange(0, 100))
+	if c.Count() != 4 {
+		t.Errorf("expected the counter to count 4 integers, got %d", c.Count())
+	}
+
+	if c := NewCounter(EmptySet[int]()); c.Count() != 0 || c.Rank(5) != 0 {
+		t.Errorf("expected an empty counter")
+	} else if _, ok := c.Nth(0); ok {
+		t.Errorf("expected no integer in an empty counter")
+	}
+}
+
+func BenchmarkCounter_Nth(b *testing.B) {
+	s := EmptySet[int]()
+	for i := 0; i < 10000; i++ {
+		s.Add(NewRange(i*10, i*10+5))
+	}
+	c := NewCounter(s)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Nth(i % 50000)
+	}
+}
+
+func ExampleNth() {
+	// allocated IDs
+	s := EmptySet[int]().Add(NewRange(100, 110), NewRange(200, 205))
+
+	fmt.Println(Count(s))
+	fmt.Println(Nth(s, 12))
+	fmt.Println(Rank(s, 203))
+
+	// a counter answers repeated lookups
+	c := NewCounter(s)
+	fmt.Println(c.Nth(12))
+	// Output:
+	// 15
+	// 202 true
+	// 13
+	// 202 true
+}
@@ -0,0 +1,107 @@
+package intervalset
+
+import "sort"
+
+// CoverageMap counts how many intervals cover each portion of the domain.
+// Unlike IntervalSet, which merges overlapping intervals, it keeps track of the depth of the coverage,
+// e.g. the number of concurrent sessions over a day.
+type CoverageMap[T any] struct {
+	// levels[k] contains the portions covered by at least k+1 intervals:
+	// |   |
+	// | T |---------------------------------->
+	// | Q |   -----------
+	// | Q |        -----------
+	// | Q |             -----
+	// |---|
+	// | 0 |   ----------------
+	// | 1 |        ------
+	// | 2 |             -
+	// |   |
+	levels []*IntervalSet[T]
+}
+
+// Segment is a portion of the domain covered by a constant number of intervals.
+type Segment[T any] struct {
+	Interval[T]
+
+	// Depth is the number of intervals covering the segment.
+	Depth int
+}
+
+// NewCoverageMap returns a new coverage map of the given intervals.
+func NewCoverageMap[T any](intervals ...Interval[T]) *CoverageMap[T] {
+	return (&CoverageMap[T]{}).Add(intervals...)
+}
+
+// Add adds the given intervals to the map, invalid and empty intervals are ignored.
+func (c *CoverageMap[T]) Add(intervals ...Interval[T]) *CoverageMap[T] {
+	for _, q := range intervals {
+		c.add(q)
+	}
+	return c
+}
+
+func (c *CoverageMap[T]) add(q Interval[T]) {
+	if _, ok := sanitize(SkipInvalid, q); !ok || isEmptyInterval(q) {
+		return
+	}
+
+	// the portions of q already covered k times are now covered k+1 times,
+	// we walk down the levels so a level is not modified before being read.
+	for k := len(c.levels) - 1; k >= 0; k-- {
+		o := c.levels[k].Overlaps(q)
+		if o.IsEmpty() {
+			continue
+		}
+		if k+1 == len(c.levels) {
+			c.levels = append(c.levels, EmptySet[T]())
+		}
+		c.levels[k+1].Add(o.intervals...)
+	}
+
+	if len(c.levels) == 0 {
+		c.levels = append(c.levels, EmptySet[T]())
+	}
+	c.levels[0].Add(q)
+}
+
+// Segments returns the ordered portions of the domain covered by at least one interval along with their depth.
+// Consecutive segments have different depths.
+func (c *CoverageMap[T]) Segments() []Segment[T] {
+	segments := make([]Segment[T], 0)
+
+	for k, l := range c.levels {
+		s := l
+		if k+1 < len(c.levels) {
+			s = l.Difference(c.levels[k+1])
+		}
+		for _, v := range s.intervals {
+			segments = append(segments, Segment[T]{Interval: v, Depth: k + 1})
+		}
+	}
+
+	sort.SliceStable(segments, func(i, j int) bool {
+		return compareMin(segments[i].Interval, segments[j].Min()) < 0
+	})
+
+	return segments
+}
+
+// MaxDepth returns the maximum number of intervals covering the same portion of the domain
+// along with a new set containing the portions where this maximum is reached.
+func (c *CoverageMap[T]) MaxDepth() (int, *IntervalSet[T]) {
+	if len(c.levels) == 0 {
+		return 0, EmptySet[T]()
+	}
+	return len(c.levels), c.AtLeast(len(c.levels))
+}
+
+// AtLeast returns a new set containing the portions of the domain covered by at least k intervals.
+// A k lower than 1 is treated as 1.
+func (c *CoverageMap[T]) AtLeast(k int) *IntervalSet[T] {
+	k = max(k, 1)
+	if k > len(c.levels) {
+		return EmptySet[T]()
+	}
+	return EmptySet[T]().Add(c.levels[k-1].intervals...)
+}
@@ -0,0 +1,132 @@
+package intervalset
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCoverageMap_Segments(t *testing.T) {
+	/*----------------------------------------------
+	|  T  | 1   2   3   4   5   6   7   8   9   10 |
+	| (+) | |-----------|                          |
+	| (+) |     |-----------|                      |
+	| (+) |         |---|                          |
+	| (+) |                     |-------|          |
+	| (+) |                         |-------|      |
+	------------------------------------------------
+	|  1  | |---|                   |---|          |
+	|  2  |     |---|   |---|           |---|      |
+	|  3  |         |---|                   |---|  |
+	----------------------------------------------*/
+	c := NewCoverageMap[int](
+		NewRange(1, 4),
+		NewRange(2, 5),
+		NewRange(3, 4),
+		NewRange(6, 8),
+		NewRange(7, 9),
+	)
+
+	expected := []Segment[int]{
+		{NewRange(1, 2), 1},
+		{NewRange(2, 3), 2},
+		{NewRange(3, 4), 3},
+		{NewRange(4, 5), 1},
+		{NewRange(6, 7), 1},
+		{NewRange(7, 8), 2},
+		{NewRange(8, 9), 1},
+	}
+
+	got := c.Segments()
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d segments, got %d: %v", len(expected), len(got), got)
+	}
+
+	for i, s := range expected {
+		if !got[i].Equal(s.Interval) || got[i].Depth != s.Depth {
+			t.Errorf("expected segment %d to be %v, got %v", i, s, got[i])
+		}
+	}
+}
+
+func TestCoverageMap_AtLeast(t *testing.T) {
+	c := NewCoverageMap[int](
+		NewRange(1, 4),
+		NewRange(2, 5),
+		NewRange(3, 4),
+		NewRange(4, 6),
+	)
+
+	var table = []struct {
+		k int
+		e *IntervalSet[int]
+	}{
+		{0, genExpectedRangeSet([]Interval[int]{NewRange(1, 6)})},
+		{1, genExpectedRangeSet([]Interval[int]{NewRange(1, 6)})},
+		{2, genExpectedRangeSet([]Interval[int]{NewRange(2, 5)})},
+		{3, genExpectedRangeSet([]Interval[int]{NewRange(3, 4)})},
+		{4, genExpectedRangeSet(nil)},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			got := c.AtLeast(tc.k)
+			if !got.Equal(tc.e) {
+				t.Errorf("both sets should be equal, expected %v, got %v", tc.e, got.AsSlice())
+			}
+		})
+	}
+}
+
+func TestCoverageMap_MaxDepth(t *testing.T) {
+	d, s := NewCoverageMap[int]().MaxDepth()
+	if d != 0 || !s.IsEmpty() {
+		t.Errorf("expected an empty map to have no depth, got %d %v", d, s.AsSlice())
+	}
+
+	at := func(h, m int) time.Time {
+		return time.Date(2023, time.December, 1, h, m, 0, 0, time.UTC)
+	}
+
+	c := NewCoverageMap[time.Time]().Add(
+		NewPeriod(at(8, 0), at(9, 30)),
+		NewPeriod(at(9, 0), at(10, 0)),
+		NewPeriod(at(9, 15), at(9, 45)),
+		NewPeriod(at(9, 30), at(11, 0)),
+		NewPeriod(at(14, 0), at(14, 45)),
+		NewPeriod(at(14, 30), at(15, 0)),
+		NewPeriod(at(14, 40), at(16, 0)),
+	)
+
+	d, p := c.MaxDepth()
+
+	expected := genExpectedPeriodSet([]Interval[time.Time]{
+		NewPeriod(at(9, 15), at(9, 45)),
+		NewPeriod(at(14, 40), at(14, 45)),
+	})
+
+	if d != 3 {
+		t.Errorf("expected a maximum depth of 3, got %d", d)
+	}
+	if !p.Equal(expected) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, p.AsSlice())
+	}
+}
+
+func ExampleCoverageMap() {
+	c := NewCoverageMap[int](
+		NewRange(1, 4),
+		NewRange(2, 5),
+		NewRange(3, 4),
+	)
+
+	for _, s := range c.Segments() {
+		fmt.Printf("%d - %d: %d\n", s.Min(), s.Max(), s.Depth)
+	}
+
+	// Output:
+	// 1 - 2: 1
+	// 2 - 3: 2
+	// 3 - 4: 3
+	// 4 - 5: 1
+}
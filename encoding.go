@@ -0,0 +1,399 @@
+package intervalset
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// binaryVersion is the version of the format produced by MarshalBinary.
+const binaryVersion byte = 1
+
+// Kinds of values stored in the binary format.
+const (
+	kindInt byte = iota + 1
+	kindUint
+	kindFloat
+	kindTime
+)
+
+// ErrInvalidBinary is returned when decoding malformed binary data.
+var ErrInvalidBinary = errors.New("intervalset: invalid binary data")
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+//
+// The encoding starts with a version and the kind of values, followed by the
+// number of intervals and, for periods, a table of locations. Each interval is
+// then encoded as the varint delta between its minimum value and the maximum
+// value of the previous interval, followed by the varint delta between its
+// maximum and minimum values. Periods are encoded as Unix nanoseconds and the
+// index of the location of each limit.
+//
+// Sets of named types, such as `type port uint16`, cannot be decoded since their
+// intervals cannot be built, so they are rejected rather than encoded.
+func (p *IntervalSet[T]) MarshalBinary() ([]byte, error) {
+	k := kindOf[T]()
+	if k == 0 || !decodable[T]() {
+		var v T
+		return nil, fmt.Errorf("intervalset: cannot encode intervals of type %T", v)
+	}
+
+	b := []byte{binaryVersion, k}
+	b = binary.AppendUvarint(b, uint64(len(p.intervals)))
+
+	var locs []zone
+	if k == kindTime {
+		for _, v := range p.intervals {
+			for _, t := range [2]T{v.Min(), v.Max()} {
+				if z := zoneOf(any(t).(time.Time)); indexOfZone(locs, z) < 0 {
+					locs = append(locs, z)
+				}
+			}
+		}
+
+		b = binary.AppendUvarint(b, uint64(len(locs)))
+		for _, z := range locs {
+			b = binary.AppendUvarint(b, uint64(len(z.name)))
+			b = append(b, z.name...)
+			b = binary.AppendVarint(b, int64(z.offset))
+		}
+	}
+
+	var prev uint64
+	for _, v := range p.intervals {
+		l, err := toBits(v.Min())
+		if err != nil {
+			return nil, err
+		}
+		u, err := toBits(v.Max())
+		if err != nil {
+			return nil, err
+		}
+
+		b = binary.AppendVarint(b, int64(l-prev))
+		b = binary.AppendVarint(b, int64(u-l))
+		prev = u
+
+		if k == kindTime {
+			b = binary.AppendUvarint(b, uint64(indexOfZone(locs, zoneOf(any(v.Min()).(time.Time)))))
+			b = binary.AppendUvarint(b, uint64(indexOfZone(locs, zoneOf(any(v.Max()).(time.Time)))))
+		}
+	}
+
+	return b, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// It replaces the intervals of the set with the decoded intervals, the set keeps its options.
+func (p *IntervalSet[T]) UnmarshalBinary(data []byte) error {
+	k := kindOf[T]()
+	if k == 0 || !decodable[T]() {
+		var v T
+		return fmt.Errorf("intervalset: cannot decode intervals of type %T", v)
+	}
+
+	d := decoder{data: data}
+
+	if v := d.byte(); d.err == nil && v != binaryVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidBinary, v)
+	}
+	if v := d.byte(); d.err == nil && v != k {
+		return fmt.Errorf("%w: unexpected kind of values %d", ErrInvalidBinary, v)
+	}
+
+	// each interval takes at least two bytes,
+	// we do not want to allocate more than what the data can hold.
+	n := d.count(2)
+
+	var locs []*time.Location
+	if k == kindTime {
+		c := d.count(2)
+		locs = make([]*time.Location, 0, c)
+		for i := 0; i < c && d.err == nil; i++ {
+			name := d.string()
+			offset := d.varint()
+			locs = append(locs, decodeLocation(name, offset))
+		}
+	}
+
+	intervals := make([]Interval[T], 0, n)
+
+	var prev uint64
+	for i := 0; i < n && d.err == nil; i++ {
+		l := prev + uint64(d.varint())
+		u := l + uint64(d.varint())
+		prev = u
+
+		lv, err := fromBits[T](l)
+		if err != nil {
+			return err
+		}
+		uv, err := fromBits[T](u)
+		if err != nil {
+			return err
+		}
+
+		if k == kindTime {
+			lt := any(lv).(time.Time).In(d.location(locs))
+			ut := any(uv).(time.Time).In(d.location(locs))
+			lv, uv = any(lt).(T), any(ut).(T)
+		}
+
+		v, ok := newInterval(lv, uv)
+		if !ok {
+			return fmt.Errorf("intervalset: cannot decode intervals of type %T", lv)
+		}
+
+		intervals = append(intervals, v)
+	}
+
+	if d.err != nil {
+		return d.err
+	}
+	if len(d.data) > 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidBinary, len(d.data))
+	}
+
+	// the decoded intervals must be valid, non-empty and the set ordered, as Add would
+	// have left them, otherwise the set's operations would not behave properly.
+	for i, v := range intervals {
+		if c, ok := v.(interface{ IsValid() bool }); ok && !c.IsValid() {
+			return fmt.Errorf("%w: invalid interval at index %d", ErrInvalidBinary, i)
+		}
+		if isEmptyInterval(v) {
+			return fmt.Errorf("%w: empty interval at index %d", ErrInvalidBinary, i)
+		}
+		if i > 0 && !intervals[i-1].Before(v) {
+			return fmt.Errorf("%w: unordered interval at index %d", ErrInvalidBinary, i)
+		}
+	}
+
+	p.intervals = intervals
+
+	return nil
+}
+
+// decoder reads values from binary data and records the first error encountered.
+type decoder struct {
+	data []byte
+	err  error
+}
+
+func (d *decoder) fail(what string) {
+	if d.err == nil {
+		d.err = fmt.Errorf("%w: cannot read %s", ErrInvalidBinary, what)
+	}
+	d.data = nil
+}
+
+func (d *decoder) byte() byte {
+	if len(d.data) == 0 {
+		d.fail("byte")
+		return 0
+	}
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b
+}
+
+func (d *decoder) uvarint() uint64 {
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.fail("uvarint")
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *decoder) varint() int64 {
+	v, n := binary.Varint(d.data)
+	if n <= 0 {
+		d.fail("varint")
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+// count reads a number of elements, each of them taking at least size bytes.
+func (d *decoder) count(size int) int {
+	v := d.uvarint()
+	if v > uint64(len(d.data)/size) {
+		d.fail("count")
+		return 0
+	}
+	return int(v)
+}
+
+func (d *decoder) string() string {
+	n := d.count(1)
+	s := string(d.data[:n])
+	d.data = d.data[n:]
+	return s
+}
+
+func (d *decoder) location(locs []*time.Location) *time.Location {
+	i := d.uvarint()
+	if i >= uint64(len(locs)) {
+		d.fail("location")
+		return time.UTC
+	}
+	return locs[i]
+}
+
+// zone identifies the location of a time along with its offset,
+// the offset being required to restore fixed zones.
+type zone struct {
+	name   string
+	offset int
+}
+
+func zoneOf(t time.Time) zone {
+	_, o := t.Zone()
+	return zone{name: t.Location().String(), offset: o}
+}
+
+func indexOfZone(zones []zone, z zone) int {
+	for i, v := range zones {
+		if v == z {
+			return i
+		}
+	}
+	return -1
+}
+
+// decodeLocation returns the location with the given name,
+// falling back to a fixed zone when the location cannot be loaded.
+func decodeLocation(name string, offset int64) *time.Location {
+	switch name {
+	case "UTC":
+		return time.UTC
+	case "Local":
+		return time.Local
+	case "":
+		return time.FixedZone(name, int(offset))
+	}
+	if l, err := time.LoadLocation(name); err == nil {
+		return l
+	}
+	return time.FixedZone(name, int(offset))
+}
+
+// kindOf returns the kind of values of type T stored in the binary format or 0 when T is not supported.
+func kindOf[T any]() byte {
+	var v T
+	if _, ok := any(v).(time.Time); ok {
+		return kindTime
+	}
+	switch reflect.ValueOf(&v).Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return kindInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return kindUint
+	case reflect.Float32, reflect.Float64:
+		return kindFloat
+	}
+	return 0
+}
+
+// toBits returns the 64 bits representation of v.
+func toBits[T any](v T) (uint64, error) {
+	if t, ok := any(v).(time.Time); ok {
+		n := t.UnixNano()
+		if !time.Unix(0, n).Equal(t) {
+			return 0, fmt.Errorf("intervalset: cannot encode time %s as Unix nanoseconds", t)
+		}
+		return uint64(n), nil
+	}
+	r := reflect.ValueOf(v)
+	switch r.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(r.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return r.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return math.Float64bits(r.Float()), nil
+	}
+	return 0, fmt.Errorf("intervalset: cannot encode value of type %T", v)
+}
+
+// fromBits returns the value of type T represented by the 64 bits b.
+func fromBits[T any](b uint64) (T, error) {
+	var v T
+	if _, ok := any(v).(time.Time); ok {
+		return any(time.Unix(0, int64(b))).(T), nil
+	}
+	r := reflect.ValueOf(&v).Elem()
+	switch r.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if r.OverflowInt(int64(b)) {
+			return v, fmt.Errorf("%w: value %d overflows %T", ErrInvalidBinary, int64(b), v)
+		}
+		r.SetInt(int64(b))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if r.OverflowUint(b) {
+			return v, fmt.Errorf("%w: value %d overflows %T", ErrInvalidBinary, b, v)
+		}
+		r.SetUint(b)
+	case reflect.Float32, reflect.Float64:
+		f := math.Float64frombits(b)
+		if r.OverflowFloat(f) {
+			return v, fmt.Errorf("%w: value %g overflows %T", ErrInvalidBinary, f, v)
+		}
+		r.SetFloat(f)
+	default:
+		return v, fmt.Errorf("intervalset: cannot decode value of type %T", v)
+	}
+	return v, nil
+}
+
+// decodable reports whether newInterval can build intervals of values of type T, which
+// excludes named types such as `type port uint16`. Sets of such values cannot be decoded,
+// so they are not encoded either.
+func decodable[T any]() bool {
+	var v T
+	_, ok := newInterval(v, v)
+	return ok
+}
+
+// newInterval returns a new interval between l and u: a Period for times and a Range for numbers.
+// It reports false when there is no interval implementation for T.
+func newInterval[T any](l, u T) (Interval[T], bool) {
+	var i any
+	switch l := any(l).(type) {
+	case time.Time:
+		i = NewPeriod(l, any(u).(time.Time))
+	case int:
+		i = NewRange(l, any(u).(int))
+	case int8:
+		i = NewRange(l, any(u).(int8))
+	case int16:
+		i = NewRange(l, any(u).(int16))
+	case int32:
+		i = NewRange(l, any(u).(int32))
+	case int64:
+		i = NewRange(l, any(u).(int64))
+	case uint:
+		i = NewRange(l, any(u).(uint))
+	case uint8:
+		i = NewRange(l, any(u).(uint8))
+	case uint16:
+		i = NewRange(l, any(u).(uint16))
+	case uint32:
+		i = NewRange(l, any(u).(uint32))
+	case uint64:
+		i = NewRange(l, any(u).(uint64))
+	case uintptr:
+		i = NewRange(l, any(u).(uintptr))
+	case float32:
+		i = NewRange(l, any(u).(float32))
+	case float64:
+		i = NewRange(l, any(u).(float64))
+	}
+	v, ok := i.(Interval[T])
+	return v, ok
+}
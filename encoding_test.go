@@ -0,0 +1,305 @@
+package intervalset
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRangeSet_MarshalBinary(t *testing.T) {
+	s1 := EmptySet[int]().Add(
+		NewRange(-10, -5),
+		NewRange(1, 3),
+		NewRange(5, 7),
+		NewRange(1000, 1000000),
+	)
+
+	b, err := s1.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	s2 := EmptySet[int]()
+	if err := s2.UnmarshalBinary(b); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if !s1.Equal(s2) {
+		t.Errorf("both sets should be equal, expected %v, got %v", s1.AsSlice(), s2.AsSlice())
+	}
+
+	// version, kind, count and two bytes for each of the first three intervals
+	if len(b) > 3+2*3+5 {
+		t.Errorf("expected a compact encoding, got %d bytes", len(b))
+	}
+}
+
+func TestRangeSet_MarshalBinaryFloat(t *testing.T) {
+	s1 := EmptySet[float64]().Add(
+		NewRange(math.Inf(-1), -1.5),
+		NewRange(0.1, 0.3),
+		NewRange(2.25, math.Inf(1)),
+	)
+
+	b, err := s1.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	s2 := EmptySet[float64]()
+	if err := s2.UnmarshalBinary(b); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if !s1.Equal(s2) {
+		t.Errorf("both sets should be equal, expected %v, got %v", s1.AsSlice(), s2.AsSlice())
+	}
+}
+
+func TestPeriodSet_MarshalBinary(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("cannot load location %v", err)
+	}
+	fixed := time.FixedZone("", -3*60*60)
+
+	s1 := EmptySet[time.Time]().Add(
+		NewPeriod(
+			time.Date(2023, time.December, 1, 8, 0, 0, 0, time.UTC),
+			time.Date(2023, time.December, 1, 12, 0, 0, 0, time.UTC),
+		),
+		NewPeriod(
+			time.Date(2023, time.December, 2, 8, 0, 0, 0, paris),
+			time.Date(2023, time.December, 2, 12, 30, 0, 10, fixed),
+		),
+		NewPeriod(
+			time.Date(2023, time.December, 3, 8, 0, 0, 0, fixed),
+			time.Date(2023, time.December, 3, 12, 0, 0, 0, paris),
+		),
+	)
+
+	b, err := s1.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	s2 := EmptySet[time.Time]()
+	if err := s2.UnmarshalBinary(b); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if !s1.Equal(s2) {
+		t.Errorf("both sets should be equal, expected %v, got %v", s1.AsSlice(), s2.AsSlice())
+	}
+
+	for i, v := range s2.AsSlice() {
+		w := s1.AsSlice()[i]
+		if v.Min().String() != w.Min().String() || v.Max().String() != w.Max().String() {
+			t.Errorf("expected locations to be preserved, expected %v, got %v", w, v)
+		}
+	}
+}
+
+func TestPeriodSet_MarshalBinaryOutOfRange(t *testing.T) {
+	s := EmptySet[time.Time]().Add(
+		NewPeriod(
+			time.Date(1000, time.December, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC),
+		),
+	)
+
+	if _, err := s.MarshalBinary(); err == nil {
+		t.Errorf("expected an error when encoding a time out of the Unix nanoseconds range")
+	}
+}
+
+func TestRangeSet_UnmarshalBinaryMalformed(t *testing.T) {
+	var table = []struct {
+		b []byte
+	}{
+		{nil},
+		{[]byte{}},
+		{[]byte{2, kindInt, 0}},             // unsupported version
+		{[]byte{1, kindFloat, 0}},           // unexpected kind
+		{[]byte{1, kindInt}},                // missing count
+		{[]byte{1, kindInt, 1, 2}},          // count greater than the data
+		{[]byte{1, kindInt, 1, 2, 0x80}},    // truncated varint
+		{[]byte{1, kindInt, 1, 2, 2, 0}},    // trailing bytes
+		{[]byte{1, kindInt, 1, 4, 1}},       // inverted interval
+		{[]byte{1, kindInt, 1, 6, 0}},       // empty interval
+		{[]byte{1, kindInt, 2, 2, 4, 0, 2}}, // overlapping intervals
+		{[]byte{1, kindInt, 2, 2, 4, 5, 2}}, // unordered intervals
+		{[]byte{1, kindInt, 1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0}}, // overflowing varint
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			s := EmptySet[int]().Add(NewRange(1, 2))
+			err := s.UnmarshalBinary(tc.b)
+			if !errors.Is(err, ErrInvalidBinary) {
+				t.Errorf("expected an invalid binary error, got %v", err)
+			}
+			if !s.Equal(EmptySet[int]().Add(NewRange(1, 2))) {
+				t.Errorf("expected the set to be left untouched, got %v", s.AsSlice())
+			}
+		})
+	}
+}
+
+func TestRangeSet_UnmarshalBinaryOverflow(t *testing.T) {
+	b, err := EmptySet[int]().Add(NewRange(1, 1000)).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := EmptySet[int8]().UnmarshalBinary(b); !errors.Is(err, ErrInvalidBinary) {
+		t.Errorf("expected an invalid binary error, got %v", err)
+	}
+}
+
+type port uint16
+
+func TestRangeSet_MarshalBinaryNamedType(t *testing.T) {
+	s := EmptySet[port]().Add(NewRange[port](1, 5))
+
+	// the set could not be decoded, so it must not be encoded either
+	if _, err := s.MarshalBinary(); err == nil {
+		t.Error("expected an error encoding a set of a named type")
+	}
+
+	b, err := EmptySet[uint16]().Add(NewRange[uint16](1, 5)).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := s.UnmarshalBinary(b); err == nil {
+		t.Error("expected an error decoding a set of a named type")
+	}
+	if !s.Equal(EmptySet[port]().Add(NewRange[port](1, 5))) {
+		t.Errorf("expected the set to be left untouched, got %v", s.AsSlice())
+	}
+}
+
+func FuzzRangeSet_MarshalBinary(f *testing.F) {
+	f.Add(int64(1), int64(3), int64(5), int64(7))
+	f.Add(int64(math.MinInt64), int64(0), int64(-5), int64(math.MaxInt64))
+	f.Add(int64(10), int64(11), int64(11), int64(12))
+
+	f.Fuzz(func(t *testing.T, a, b, c, d int64) {
+		if a == b || c == d {
+			t.Skip("empty ranges")
+		}
+
+		s1 := EmptySet[int64]().Add(
+			NewRange(min(a, b), max(a, b)),
+			NewRange(min(c, d), max(c, d)),
+		)
+
+		data, err := s1.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		s2 := EmptySet[int64]()
+		if err := s2.UnmarshalBinary(data); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		if !s1.Equal(s2) {
+			t.Errorf("both sets should be equal, expected %v, got %v", s1.AsSlice(), s2.AsSlice())
+		}
+	})
+}
+
+func FuzzRangeSet_UnmarshalBinary(f *testing.F) {
+	for _, s := range []*IntervalSet[int]{
+		EmptySet[int](),
+		EmptySet[int]().Add(NewRange(1, 3), NewRange(5, 7)),
+		EmptySet[int]().Add(NewRange(-100, 100), NewRange(1<<40, 1<<50)),
+	} {
+		b, err := s.MarshalBinary()
+		if err != nil {
+			f.Fatalf("unexpected error %v", err)
+		}
+		f.Add(b)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s1 := EmptySet[int]()
+		if err := s1.UnmarshalBinary(data); err != nil {
+			return
+		}
+
+		for i, v := range s1.AsSlice() {
+			if !v.(Range[int]).IsValid() {
+				t.Fatalf("invalid interval decoded %v", v)
+			}
+			if i > 0 && !s1.AsSlice()[i-1].Before(v) {
+				t.Fatalf("unordered intervals decoded %v", s1.AsSlice())
+			}
+		}
+
+		b, err := s1.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		s2 := EmptySet[int]()
+		if err := s2.UnmarshalBinary(b); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		if !s1.Equal(s2) {
+			t.Errorf("both sets should be equal, expected %v, got %v", s1.AsSlice(), s2.AsSlice())
+		}
+	})
+}
+
+func FuzzPeriodSet_UnmarshalBinary(f *testing.F) {
+	b, err := EmptySet[time.Time]().Add(
+		NewPeriod(
+			time.Date(2023, time.December, 1, 8, 0, 0, 0, time.UTC),
+			time.Date(2023, time.December, 1, 12, 0, 0, 0, time.FixedZone("X", 3600)),
+		),
+	).MarshalBinary()
+	if err != nil {
+		f.Fatalf("unexpected error %v", err)
+	}
+	f.Add(b)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s1 := EmptySet[time.Time]()
+		if err := s1.UnmarshalBinary(data); err != nil {
+			return
+		}
+
+		b, err := s1.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		s2 := EmptySet[time.Time]()
+		if err := s2.UnmarshalBinary(b); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		if !s1.Equal(s2) {
+			t.Errorf("both sets should be equal, expected %v, got %v", s1.AsSlice(), s2.AsSlice())
+		}
+	})
+}
+
+func ExampleIntervalSet_MarshalBinary() {
+	s1 := EmptySet[int]().Add(NewRange(1, 3), NewRange(5, 7))
+
+	b, _ := s1.MarshalBinary()
+
+	s2 := EmptySet[int]()
+	_ = s2.UnmarshalBinary(b)
+
+	fmt.Println(len(b), s1.Equal(s2))
+
+	// Output:
+	// 7 true
+}
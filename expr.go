@@ -0,0 +1,312 @@
+package intervalset
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+// ErrInvalidExpr is returned when parsing or evaluating a malformed set expression.
+var ErrInvalidExpr = errors.New("intervalset: invalid expression")
+
+// ExprError describes an error located in a set expression.
+type ExprError struct {
+	// Pos is the position of the offending token, in characters from the start of the expression.
+	Pos int
+	Msg string
+}
+
+func (e *ExprError) Error() string {
+	return fmt.Sprintf("%s at position %d: %s", ErrInvalidExpr, e.Pos, e.Msg)
+}
+
+func (e *ExprError) Unwrap() error {
+	return ErrInvalidExpr
+}
+
+// Env holds what a set expression is evaluated against.
+type Env[T any] struct {
+	// Sets are the sets the names of the expression refer to.
+	Sets map[string]*IntervalSet[T]
+
+	// Window is the interval complements are computed within.
+	// An expression using a complement cannot be evaluated without a window.
+	Window Interval[T]
+}
+
+// Expr is a parsed set expression.
+//
+// An expression combines named sets with the following operators,
+// listed from the highest to the lowest precedence:
+//   - complement within the window: ~a or ∁a
+//   - intersection: a ∩ b or a & b
+//   - union: a ∪ b or a | b, difference: a − b or a - b, symmetric difference: a △ b or a ^ b
+//
+// Operators of the same precedence are evaluated from left to right and parentheses
+// can be used to group operations. Names are made of letters, digits, '_' and '.'.
+type Expr struct {
+	root exprNode
+}
+
+// ParseExpr parses a set expression.
+func ParseExpr(src string) (*Expr, error) {
+	p := &exprParser{tokens: tokenize([]rune(src))}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, t.errorf("unexpected %s", t)
+	}
+	return &Expr{root: root}, nil
+}
+
+// Names returns the names of the sets the expression refers to, in order of appearance.
+func (e *Expr) Names() []string {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	e.root.walk(func(n exprNode) {
+		if n.op.kind == tokName && !seen[n.op.text] {
+			seen[n.op.text] = true
+			names = append(names, n.op.text)
+		}
+	})
+	return names
+}
+
+// Evaluate returns a new set resulting from the evaluation of the expression.
+// The sets of the environment are left untouched.
+func Evaluate[T any](e *Expr, env Env[T]) (*IntervalSet[T], error) {
+	return evaluate(e.root, env)
+}
+
+// Eval parses and evaluates a set expression.
+func Eval[T any](src string, env Env[T]) (*IntervalSet[T], error) {
+	e, err := ParseExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	return Evaluate(e, env)
+}
+
+func evaluate[T any](n exprNode, env Env[T]) (*IntervalSet[T], error) {
+	switch n.op.kind {
+	case tokName:
+		s, ok := env.Sets[n.op.text]
+		if !ok || s == nil {
+			return nil, n.op.errorf("undefined set %q", n.op.text)
+		}
+		c := emptySetOf[T](s.config())
+		c.intervals = append(c.intervals, s.intervals...)
+		return c, nil
+	case tokComplement:
+		if env.Window == nil || env.Window.IsZero() {
+			return nil, n.op.errorf("complement requires a window")
+		}
+		x, err := evaluate(*n.left, env)
+		if err != nil {
+			return nil, err
+		}
+		return x.Complement(env.Window), nil
+	}
+
+	l, err := evaluate(*n.left, env)
+	if err != nil {
+		return nil, err
+	}
+	r, err := evaluate(*n.right, env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op.kind {
+	case tokUnion:
+		return Union(l, r), nil
+	case tokIntersection:
+		return Intersection(l, r), nil
+	case tokDifference:
+		return l.Difference(r), nil
+	default:
+		return Union(l.Difference(r), r.Difference(l)), nil
+	}
+}
+
+// exprNode is a node of the syntax tree: a name, a complement with its operand in left
+// or a binary operation.
+type exprNode struct {
+	op          token
+	left, right *exprNode
+}
+
+func (n exprNode) walk(f func(exprNode)) {
+	f(n)
+	if n.left != nil {
+		n.left.walk(f)
+	}
+	if n.right != nil {
+		n.right.walk(f)
+	}
+}
+
+type exprParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *exprParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// parseExpr parses a sequence of terms separated by union, difference or symmetric difference operators.
+func (p *exprParser) parseExpr() (exprNode, error) {
+	l, err := p.parseTerm()
+	if err != nil {
+		return l, err
+	}
+	for {
+		op := p.peek()
+		if op.kind != tokUnion && op.kind != tokDifference && op.kind != tokSymmetricDifference {
+			return l, nil
+		}
+		p.next()
+
+		r, err := p.parseTerm()
+		if err != nil {
+			return r, err
+		}
+		left := l
+		l = exprNode{op: op, left: &left, right: &r}
+	}
+}
+
+// parseTerm parses a sequence of factors separated by intersection operators.
+func (p *exprParser) parseTerm() (exprNode, error) {
+	l, err := p.parseFactor()
+	if err != nil {
+		return l, err
+	}
+	for p.peek().kind == tokIntersection {
+		op := p.next()
+
+		r, err := p.parseFactor()
+		if err != nil {
+			return r, err
+		}
+		left := l
+		l = exprNode{op: op, left: &left, right: &r}
+	}
+	return l, nil
+}
+
+// parseFactor parses a name, a complement or a parenthesized expression.
+func (p *exprParser) parseFactor() (exprNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokName:
+		return exprNode{op: t}, nil
+	case tokComplement:
+		x, err := p.parseFactor()
+		if err != nil {
+			return x, err
+		}
+		return exprNode{op: t, left: &x}, nil
+	case tokLeftParen:
+		x, err := p.parseExpr()
+		if err != nil {
+			return x, err
+		}
+		if c := p.next(); c.kind != tokRightParen {
+			return x, c.errorf("expected ')' to close '(' at position %d, got %s", t.pos, c)
+		}
+		return x, nil
+	case tokInvalid:
+		return exprNode{}, t.errorf("unexpected character %q", t.text)
+	}
+	return exprNode{}, t.errorf("expected a set name, '(' or a complement, got %s", t)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokInvalid
+	tokName
+	tokLeftParen
+	tokRightParen
+	tokUnion
+	tokIntersection
+	tokDifference
+	tokSymmetricDifference
+	tokComplement
+)
+
+var operators = map[rune]tokenKind{
+	'(': tokLeftParen,
+	')': tokRightParen,
+	'∪': tokUnion,
+	'|': tokUnion,
+	'∩': tokIntersection,
+	'&': tokIntersection,
+	'−': tokDifference,
+	'-': tokDifference,
+	'△': tokSymmetricDifference,
+	'^': tokSymmetricDifference,
+	'∁': tokComplement,
+	'~': tokComplement,
+}
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+func (t token) errorf(format string, args ...any) error {
+	return &ExprError{Pos: t.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func isNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
+
+// tokenize splits the expression into tokens, it always ends with an EOF token.
+func tokenize(src []rune) []token {
+	tokens := make([]token, 0)
+	for i := 0; i < len(src); {
+		r := src[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case isNameRune(r):
+			j := i
+			for j < len(src) && isNameRune(src[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokName, text: string(src[i:j]), pos: i})
+			i = j
+		default:
+			kind, ok := operators[r]
+			if !ok {
+				kind = tokInvalid
+			}
+			tokens = append(tokens, token{kind: kind, text: string(r), pos: i})
+			i++
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(src)})
+}
@@ -0,0 +1,139 @@
+package intervalset
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	/*----------------------------------------------
+	|  T  | 1   2   3   4   5   6   7   8   9   10 |
+	| a   |     |-----------|                      |
+	| b   |             |-----------|              |
+	| c   |         |---|       |-----------|      |
+	----------------------------------------------*/
+	env := Env[int]{
+		Sets: map[string]*IntervalSet[int]{
+			"a": EmptySet[int]().Add(NewRange(2, 5)),
+			"b": EmptySet[int]().Add(NewRange(4, 7)),
+			"c": EmptySet[int]().Add(NewRange(3, 4), NewRange(6, 9)),
+		},
+		Window: NewRange(1, 10),
+	}
+
+	var table = []struct {
+		src      string
+		expected *IntervalSet[int]
+	}{
+		{"a", genExpectedRangeSet([]Interval[int]{NewRange(2, 5)})},
+		{"a ∪ b", genExpectedRangeSet([]Interval[int]{NewRange(2, 7)})},
+		{"a | b", genExpectedRangeSet([]Interval[int]{NewRange(2, 7)})},
+		{"a ∩ b", genExpectedRangeSet([]Interval[int]{NewRange(4, 5)})},
+		{"a − b", genExpectedRangeSet([]Interval[int]{NewRange(2, 4)})},
+		{"a △ b", genExpectedRangeSet([]Interval[int]{NewRange(2, 4), NewRange(5, 7)})},
+		{"a ^ b", genExpectedRangeSet([]Interval[int]{NewRange(2, 4), NewRange(5, 7)})},
+		{"~a", genExpectedRangeSet([]Interval[int]{NewRange(1, 2), NewRange(5, 10)})},
+		{"∁(a ∪ b)", genExpectedRangeSet([]Interval[int]{NewRange(1, 2), NewRange(7, 10)})},
+		// intersection has a higher precedence
+		{"a ∪ b ∩ c", genExpectedRangeSet([]Interval[int]{NewRange(2, 5), NewRange(6, 7)})},
+		{"(a ∪ b) ∩ c", genExpectedRangeSet([]Interval[int]{NewRange(3, 4), NewRange(6, 7)})},
+		{"(a ∪ b) − c ∩ a", genExpectedRangeSet([]Interval[int]{NewRange(2, 3), NewRange(4, 7)})},
+		// operators of the same precedence are evaluated from left to right
+		{"a - b | c", genExpectedRangeSet([]Interval[int]{NewRange(2, 4), NewRange(6, 9)})},
+		{"a - (b | c)", genExpectedRangeSet([]Interval[int]{NewRange(2, 3)})},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			got, err := Eval(tc.src, env)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !got.Equal(tc.expected) {
+				t.Errorf("both sets should be equal, expected %v, got %v", tc.expected, got.AsSlice())
+			}
+		})
+	}
+
+	// the sets of the environment are left untouched
+	if !env.Sets["a"].Equal(genExpectedRangeSet([]Interval[int]{NewRange(2, 5)})) {
+		t.Errorf("expected the set to be left untouched, got %v", env.Sets["a"].AsSlice())
+	}
+}
+
+func TestEval_Errors(t *testing.T) {
+	env := Env[int]{
+		Sets: map[string]*IntervalSet[int]{
+			"a": EmptySet[int]().Add(NewRange(2, 5)),
+		},
+	}
+
+	var table = []struct {
+		src string
+		pos int
+	}{
+		{"", 0},
+		{"a ∪", 3},
+		{"a b", 2},
+		{"(a ∪ a", 6},
+		{"a ∪ )", 4},
+		{"a + a", 2},
+		{"a ∩ undefined", 4},
+		{"a ∪ ~a", 4},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			_, err := Eval(tc.src, env)
+			if !errors.Is(err, ErrInvalidExpr) {
+				t.Fatalf("expected %v, got %v", ErrInvalidExpr, err)
+			}
+
+			var e *ExprError
+			if !errors.As(err, &e) {
+				t.Fatalf("expected an ExprError, got %T", err)
+			}
+			if e.Pos != tc.pos {
+				t.Errorf("expected the error at position %d, got %d: %v", tc.pos, e.Pos, err)
+			}
+		})
+	}
+}
+
+func TestExpr_Names(t *testing.T) {
+	e, err := ParseExpr("(oncall ∪ backup) − holidays ∩ ~oncall")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := []string{"oncall", "backup", "holidays"}
+	if fmt.Sprint(e.Names()) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, e.Names())
+	}
+}
+
+func ExampleEval() {
+	env := Env[int]{
+		Sets: map[string]*IntervalSet[int]{
+			"oncall":   EmptySet[int]().Add(NewRange(0, 8)),
+			"backup":   EmptySet[int]().Add(NewRange(8, 12)),
+			"holidays": EmptySet[int]().Add(NewRange(3, 5)),
+		},
+		Window: NewRange(0, 24),
+	}
+
+	s, _ := Eval("(oncall ∪ backup) − holidays", env)
+	fmt.Println(s.AsSlice())
+
+	s, _ = Eval("~(oncall ∪ backup)", env)
+	fmt.Println(s.AsSlice())
+
+	_, err := Eval("oncall ∪ (backup", env)
+	fmt.Println(err)
+
+	// Output:
+	// [{0 3} {5 12}]
+	// [{12 24}]
+	// intervalset: invalid expression at position 16: expected ')' to close '(' at position 9, got end of expression
+}
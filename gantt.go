@@ -0,0 +1,224 @@
+package intervalset
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+)
+
+// GanttOptions defines how WriteGanttSVG and WriteGanttHTML draw the chart.
+type GanttOptions struct {
+	// Title is drawn above the chart when not empty.
+	Title string
+
+	// Window is the period drawn on the time axis, defaults to the period covered by the rows.
+	Window Interval[time.Time]
+
+	// Scale is the duration represented by a pixel. When zero, the scale is
+	// computed so the time axis is Width pixels wide.
+	Scale time.Duration
+
+	// Width is the width of the time axis in pixels when Scale is zero, defaults to 800.
+	Width int
+
+	// RowHeight is the height of a row in pixels, defaults to 24.
+	RowHeight int
+
+	// LabelWidth is the width of the column of labels in pixels, defaults to 120.
+	LabelWidth int
+
+	// Tick is the duration between two tick marks, defaults to a duration leaving about 80 pixels between them.
+	// It is raised when it would leave less than about 40 pixels between them.
+	Tick time.Duration
+
+	// TimeFormat is the layout of the tick labels, defaults to a layout suited to the tick duration.
+	TimeFormat string
+
+	// Colors are the colors of the bars, cycled through the rows, defaults to a palette of 6 colors.
+	Colors []string
+
+	// Background is the background color, defaults to white.
+	Background string
+}
+
+var defaultColors = []string{"#4e79a7", "#f28e2b", "#59a14f", "#e15759", "#76b7b2", "#b07aa1"}
+
+// tickDurations are the durations considered when picking the duration between two tick marks.
+var tickDurations = []time.Duration{
+	time.Minute, 5 * time.Minute, 15 * time.Minute, 30 * time.Minute,
+	time.Hour, 3 * time.Hour, 6 * time.Hour, 12 * time.Hour,
+	24 * time.Hour, 7 * 24 * time.Hour, 28 * 24 * time.Hour,
+}
+
+// WriteGanttSVG writes a standalone SVG document drawing the rows as a Gantt chart,
+// one row per track with its label on the left and a time axis at the top.
+func WriteGanttSVG(w io.Writer, opts GanttOptions, rows ...Track[time.Time]) error {
+	_, err := io.WriteString(w, gantt(opts, rows))
+	return err
+}
+
+// WriteGanttHTML writes a standalone HTML document embedding the chart drawn by WriteGanttSVG.
+func WriteGanttHTML(w io.Writer, opts GanttOptions, rows ...Track[time.Time]) error {
+	title := opts.Title
+	if title == "" {
+		title = "Gantt chart"
+	}
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(title))
+	b.WriteString("</head>\n<body>\n")
+	b.WriteString(gantt(opts, rows))
+	b.WriteString("</body>\n</html>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func gantt(opts GanttOptions, rows []Track[time.Time]) string {
+	rowHeight := opts.RowHeight
+	if rowHeight <= 0 {
+		rowHeight = 24
+	}
+	labelWidth := opts.LabelWidth
+	if labelWidth <= 0 {
+		labelWidth = 120
+	}
+	colors := opts.Colors
+	if len(colors) == 0 {
+		colors = defaultColors
+	}
+	background := opts.Background
+	if background == "" {
+		background = "white"
+	}
+
+	window := opts.Window
+	if window == nil || window.IsZero() {
+		window = extentOf(rows)
+	}
+
+	start, scale, width := time.Time{}, opts.Scale, opts.Width
+	if window != nil {
+		start = window.Min()
+		span := window.Max().Sub(start)
+		if scale <= 0 {
+			if width <= 0 {
+				width = 800
+			}
+			scale = max(span/time.Duration(width), 1)
+		}
+		width = int((span + scale - 1) / scale)
+	}
+	width = max(width, 1)
+
+	x := func(t time.Time) float64 {
+		return float64(labelWidth) + float64(t.Sub(start))/float64(scale)
+	}
+
+	top := 8
+	if opts.Title != "" {
+		top += 24
+	}
+	axis := top + 16
+	height := axis + 8 + len(rows)*rowHeight + 8
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\" font-family=\"sans-serif\" font-size=\"12\">\n",
+		labelWidth+width+16, height, labelWidth+width+16, height)
+	fmt.Fprintf(&b, "<rect width=\"100%%\" height=\"100%%\" fill=\"%s\"/>\n", html.EscapeString(background))
+
+	if opts.Title != "" {
+		fmt.Fprintf(&b, "<text x=\"8\" y=\"20\" font-size=\"16\" font-weight=\"bold\">%s</text>\n", html.EscapeString(opts.Title))
+	}
+
+	// time axis
+	if window != nil {
+		tick := opts.Tick
+		if tick <= 0 {
+			tick = tickDurations[len(tickDurations)-1]
+			for _, d := range tickDurations {
+				if float64(d)/float64(scale) >= 80 {
+					tick = d
+					break
+				}
+			}
+		}
+		tick = clampTick(tick, window.Max().Sub(start), max(width/40, 1))
+		layout := opts.TimeFormat
+		if layout == "" {
+			layout = tickLayout(tick, window)
+		}
+
+		b.WriteString("<g class=\"axis\" stroke=\"#ccc\">\n")
+		for t := firstTick(start, tick); !t.After(window.Max()); t = t.Add(tick) {
+			fmt.Fprintf(&b, "<line x1=\"%.1f\" y1=\"%d\" x2=\"%.1f\" y2=\"%d\"/>\n", x(t), axis+4, x(t), height-8)
+			fmt.Fprintf(&b, "<text x=\"%.1f\" y=\"%d\" stroke=\"none\" fill=\"#333\" text-anchor=\"middle\">%s</text>\n",
+				x(t), axis, html.EscapeString(t.Format(layout)))
+		}
+		b.WriteString("</g>\n")
+	}
+
+	// rows
+	for i, r := range rows {
+		y := axis + 8 + i*rowHeight
+		color := html.EscapeString(colors[i%len(colors)])
+
+		fmt.Fprintf(&b, "<g class=\"row\">\n<text x=\"8\" y=\"%d\" dominant-baseline=\"middle\">%s</text>\n",
+			y+rowHeight/2, html.EscapeString(r.Label))
+
+		if window != nil && r.Set != nil {
+			for _, q := range r.Set.intervals {
+				if q = q.Intersect(window); q.IsZero() {
+					continue
+				}
+				fmt.Fprintf(&b, "<rect x=\"%.1f\" y=\"%d\" width=\"%.1f\" height=\"%d\" rx=\"2\" fill=\"%s\"><title>%s – %s</title></rect>\n",
+					x(q.Min()), y+2, max(x(q.Max())-x(q.Min()), 1), rowHeight-4, color,
+					q.Min().Format(time.RFC3339), q.Max().Format(time.RFC3339))
+			}
+		}
+		b.WriteString("</g>\n")
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// clampTick returns a tick duration of at least tick leaving at most n ticks over the span,
+// so a small tick over a long window does not produce millions of tick marks.
+func clampTick(tick, span time.Duration, n int) time.Duration {
+	if span/tick <= time.Duration(n) {
+		return tick
+	}
+	for _, d := range tickDurations {
+		if d > tick && span/d <= time.Duration(n) {
+			return d
+		}
+	}
+	return (span + time.Duration(n) - 1) / time.Duration(n)
+}
+
+// firstTick returns the first multiple of tick, counted from midnight, at or after t.
+func firstTick(t time.Time, tick time.Duration) time.Time {
+	y, m, d := t.Date()
+	s := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	if tick >= 24*time.Hour {
+		for s.Before(t) {
+			s = s.AddDate(0, 0, 1)
+		}
+		return s
+	}
+	return s.Add((t.Sub(s) + tick - 1) / tick * tick)
+}
+
+func tickLayout(tick time.Duration, window Interval[time.Time]) string {
+	switch {
+	case tick >= 24*time.Hour:
+		return "2006-01-02"
+	case window.Max().Sub(window.Min()) > 24*time.Hour:
+		return "01-02 15:04"
+	}
+	return "15:04"
+}
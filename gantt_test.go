@@ -0,0 +1,190 @@
+package intervalset
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type svgDocument struct {
+	Width  int        `xml:"width,attr"`
+	Height int        `xml:"height,attr"`
+	Texts  []string   `xml:"text"`
+	Groups []svgGroup `xml:"g"`
+}
+
+type svgGroup struct {
+	Class string     `xml:"class,attr"`
+	Texts []string   `xml:"text"`
+	Lines []struct{} `xml:"line"`
+	Rects []svgRect  `xml:"rect"`
+}
+
+type svgRect struct {
+	X     float64 `xml:"x,attr"`
+	Width float64 `xml:"width,attr"`
+	Fill  string  `xml:"fill,attr"`
+	Title string  `xml:"title"`
+}
+
+func TestWriteGanttSVG(t *testing.T) {
+	s := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	rows := []Track[time.Time]{
+		{"Alice & Bob", EmptySet[time.Time]().Add(NewPeriod(s, s.Add(2*time.Hour)), NewPeriod(s.Add(3*time.Hour), s.Add(4*time.Hour)))},
+		{"<Carol>", EmptySet[time.Time]().Add(NewPeriod(s.Add(time.Hour), s.Add(5*time.Hour)))},
+	}
+
+	var table = []struct {
+		opts   GanttOptions
+		width  int
+		ticks  []string
+		rects  [][2]float64
+		colors []string
+	}{
+		{
+			GanttOptions{Width: 500},
+			120 + 500 + 16,
+			[]string{"09:00", "10:00", "11:00", "12:00", "13:00", "14:00"},
+			[][2]float64{{120, 200}, {420, 100}, {220, 400}},
+			defaultColors[:2],
+		},
+		{
+			GanttOptions{Scale: time.Minute, Tick: 2 * time.Hour, LabelWidth: 100, Colors: []string{"red"}},
+			100 + 300 + 16,
+			[]string{"10:00", "12:00", "14:00"},
+			[][2]float64{{100, 120}, {280, 60}, {160, 240}},
+			[]string{"red", "red"},
+		},
+		{
+			// bars are clipped to the window
+			GanttOptions{Window: NewPeriod(s.Add(time.Hour), s.Add(3*time.Hour)), Scale: time.Minute, Tick: time.Hour, TimeFormat: "15h"},
+			120 + 120 + 16,
+			[]string{"10h", "11h", "12h"},
+			[][2]float64{{120, 60}, {120, 120}},
+			defaultColors[:2],
+		},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			var b bytes.Buffer
+			if err := WriteGanttSVG(&b, tc.opts, rows...); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			var doc svgDocument
+			if err := xml.Unmarshal(b.Bytes(), &doc); err != nil {
+				t.Fatalf("expected a well-formed document, got %v\n%s", err, b.String())
+			}
+
+			if doc.Width != tc.width {
+				t.Errorf("expected a width of %d, got %d", tc.width, doc.Width)
+			}
+			if len(doc.Groups) != 3 || doc.Groups[0].Class != "axis" {
+				t.Fatalf("expected an axis and 2 rows, got %v", doc.Groups)
+			}
+			if fmt.Sprint(doc.Groups[0].Texts) != fmt.Sprint(tc.ticks) {
+				t.Errorf("expected ticks %v, got %v", tc.ticks, doc.Groups[0].Texts)
+			}
+
+			rects := make([][2]float64, 0)
+			for j, g := range doc.Groups[1:] {
+				if g.Texts[0] != rows[j].Label {
+					t.Errorf("expected label %q, got %q", rows[j].Label, g.Texts[0])
+				}
+				for _, r := range g.Rects {
+					if r.Fill != tc.colors[j] {
+						t.Errorf("expected color %s, got %s", tc.colors[j], r.Fill)
+					}
+					rects = append(rects, [2]float64{r.X, r.Width})
+				}
+			}
+			if fmt.Sprint(rects) != fmt.Sprint(tc.rects) {
+				t.Errorf("expected bars %v, got %v", tc.rects, rects)
+			}
+		})
+	}
+}
+
+func TestWriteGanttHTML(t *testing.T) {
+	s := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	rows := Track[time.Time]{"on-call", EmptySet[time.Time]().Add(NewPeriod(s, s.AddDate(0, 0, 10)))}
+
+	var b bytes.Buffer
+	if err := WriteGanttHTML(&b, GanttOptions{Title: "Rota <Q1>"}, rows); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	out := b.String()
+	for _, expected := range []string{
+		"<!DOCTYPE html>",
+		"<title>Rota &lt;Q1&gt;</title>",
+		"<svg xmlns=\"http://www.w3.org/2000/svg\"",
+		">2024-01-02</text>",
+		"</html>",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected the document to contain %q", expected)
+		}
+	}
+}
+
+func TestWriteGanttSVG_SmallTick(t *testing.T) {
+	s := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	rows := []Track[time.Time]{{"year", EmptySet[time.Time]().Add(NewPeriod(s, s.AddDate(1, 0, 0)))}}
+
+	var b bytes.Buffer
+	if err := WriteGanttSVG(&b, GanttOptions{Width: 800, Tick: time.Second}, rows...); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	var doc svgDocument
+	if err := xml.Unmarshal(b.Bytes(), &doc); err != nil {
+		t.Fatalf("expected a well-formed document, got %v", err)
+	}
+
+	// the tick is raised to leave at most about one tick every 40 pixels
+	if n := len(doc.Groups[0].Lines); n == 0 || n > 800/40+1 {
+		t.Errorf("expected at most %d ticks, got %d", 800/40+1, n)
+	}
+}
+
+func TestClampTick(t *testing.T) {
+	var table = []struct {
+		tick, span time.Duration
+		n          int
+		expected   time.Duration
+	}{
+		{time.Hour, 5 * time.Hour, 10, time.Hour},
+		{time.Second, time.Hour, 10, 15 * time.Minute},
+		{time.Minute, 24 * time.Hour, 4, 6 * time.Hour},
+		{time.Hour, 1000 * 24 * time.Hour, 2, 500 * 24 * time.Hour},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			if got := clampTick(tc.tick, tc.span, tc.n); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestWriteGanttSVG_Empty(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteGanttSVG(&b, GanttOptions{}, Track[time.Time]{"nothing", EmptySet[time.Time]()}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	var doc svgDocument
+	if err := xml.Unmarshal(b.Bytes(), &doc); err != nil {
+		t.Fatalf("expected a well-formed document, got %v", err)
+	}
+	if len(doc.Groups) != 1 || len(doc.Groups[0].Rects) != 0 {
+		t.Errorf("expected a single row without bars, got %v", doc.Groups)
+	}
+}
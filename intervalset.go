@@ -38,16 +38,49 @@ type Interval[T any] interface {
 	IsZero() bool
 }
 
-// EmptySet returns an empty set.
-func EmptySet[T any]() *IntervalSet[T] {
+// EmptySet returns an empty set configured with the given options.
+func EmptySet[T any](opts ...SetOption) *IntervalSet[T] {
+	var c setConfig
+	for _, o := range opts {
+		o(&c)
+	}
+	if kindOf[T]() != kindFloat {
+		c.tolerance = 0
+	}
+	return emptySetOf[T](c)
+}
+
+// emptySetOf returns an empty set configured with c.
+func emptySetOf[T any](c setConfig) *IntervalSet[T] {
 	return &IntervalSet[T]{
 		intervals: make([]Interval[T], 0),
+		invalid:   c.invalid,
+		tolerance: c.tolerance,
+		maxGap:    c.maxGap,
 	}
 }
 
+// config returns the options the set is configured with,
+// so the copies of the set can be configured likewise.
+func (p *IntervalSet[T]) config() setConfig {
+	return setConfig{invalid: p.invalid, tolerance: p.tolerance, maxGap: p.maxGap}
+}
+
+// emptySetLike returns an empty set configured like the first of the sets.
+func emptySetLike[T any](sets []*IntervalSet[T]) *IntervalSet[T] {
+	if len(sets) == 0 {
+		return EmptySet[T]()
+	}
+	return emptySetOf[T](sets[0].config())
+}
+
 // IntervalSet is an ordered set of intervals.
 type IntervalSet[T any] struct {
 	intervals []Interval[T]
+	onChange  func(added, removed *IntervalSet[T])
+	invalid   InvalidPolicy
+	tolerance float64
+	maxGap    float64
 }
 
 // AsSlice returns the underlying set of intervals as a slice.
@@ -61,12 +94,18 @@ func (p *IntervalSet[T]) IsEmpty() bool {
 }
 
 // Equal reports whether the set is equal to another set.
+// Bounds are compared with the greatest tolerance of both sets.
 func (p *IntervalSet[T]) Equal(q *IntervalSet[T]) bool {
 	if len(p.intervals) != len(q.intervals) {
 		return false
 	}
+	eps := max(p.tolerance, q.tolerance)
 	for i, v := range p.intervals {
-		if !v.Equal(q.intervals[i]) {
+		if eps > 0 {
+			if !near(v.Min(), q.intervals[i].Min(), eps) || !near(v.Max(), q.intervals[i].Max(), eps) {
+				return false
+			}
+		} else if !v.Equal(q.intervals[i]) {
 			return false
 		}
 	}
@@ -75,7 +114,13 @@ func (p *IntervalSet[T]) Equal(q *IntervalSet[T]) bool {
 
 // Add adds the given interval to the set.
 // Intervals will be merged with the intervals present in the set.
+// Invalid intervals are handled according to the set's InvalidPolicy and empty intervals,
+// such as [+Inf, +Inf], are dropped since they cover nothing.
 func (p *IntervalSet[T]) Add(intervals ...Interval[T]) *IntervalSet[T] {
+	if p.onChange != nil {
+		p.AddDiff(intervals...)
+		return p
+	}
 	for _, q := range intervals {
 		p.add(q)
 	}
@@ -83,6 +128,12 @@ func (p *IntervalSet[T]) Add(intervals ...Interval[T]) *IntervalSet[T] {
 }
 
 func (p *IntervalSet[T]) add(q Interval[T]) {
+	q, ok := p.prepare(q)
+	if !ok {
+		return
+	}
+	q = p.bridge(q)
+
 	// the set is empty we can simply append the interval to the set.
 	if p.IsEmpty() {
 		p.intervals = append(p.intervals, q)
@@ -149,15 +200,108 @@ func (p *IntervalSet[T]) add(q Interval[T]) {
 	p.intervals = append(p.intervals, stack...) // 👉
 }
 
+// TryAdd adds q to the set only if it does not overlap any interval of the set.
+// Intervals merely adjoining q are not considered overlapping.
+// It returns the intervals of the set overlapping q and reports whether q was added,
+// an invalid interval skipped according to the set's InvalidPolicy is not added.
+func (p *IntervalSet[T]) TryAdd(q Interval[T]) ([]Interval[T], bool) {
+	conflicts := make([]Interval[T], 0)
+
+	q, ok := p.prepare(q)
+	if !ok {
+		return conflicts, false
+	}
+
+	l, h := p.rangeOfOverlap(q)
+	for _, v := range p.intervals[l:h] {
+		if !v.Intersect(q).IsZero() {
+			conflicts = append(conflicts, v)
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return conflicts, false
+	}
+
+	if p.onChange != nil {
+		p.AddDiff(q)
+	} else {
+		p.add(q)
+	}
+	return conflicts, true
+}
+
 // Sub subtracts the given intervals from the set.
+// Invalid intervals are handled according to the set's InvalidPolicy.
 func (p *IntervalSet[T]) Sub(intervals ...Interval[T]) *IntervalSet[T] {
+	if p.onChange != nil {
+		p.SubDiff(intervals...)
+		return p
+	}
 	for _, q := range intervals {
 		p.sub(q)
 	}
 	return p
 }
 
+// AddDiff adds the given intervals to the set like Add does
+// and returns a new set containing the newly covered intervals.
+func (p *IntervalSet[T]) AddDiff(intervals ...Interval[T]) *IntervalSet[T] {
+	added := EmptySet[T]()
+	for _, q := range intervals {
+		q, ok := p.prepare(q)
+		if !ok {
+			continue
+		}
+		q = p.bridge(q)
+		if !isEmpty(q.Min(), q.Max()) {
+			added.Add(p.Complement(q).intervals...)
+		}
+		p.add(q)
+	}
+	p.notify(added, EmptySet[T]())
+	return added
+}
+
+// SubDiff subtracts the given intervals from the set like Sub does
+// and returns a new set containing the newly uncovered intervals.
+func (p *IntervalSet[T]) SubDiff(intervals ...Interval[T]) *IntervalSet[T] {
+	removed := EmptySet[T]()
+	for _, q := range intervals {
+		q, ok := p.prepare(q)
+		if !ok {
+			continue
+		}
+		if !isEmpty(q.Min(), q.Max()) {
+			removed.Add(p.Overlaps(q).intervals...)
+		}
+		p.sub(q)
+	}
+	p.notify(EmptySet[T](), removed)
+	return removed
+}
+
+// OnChange registers a function invoked with the newly covered and the newly uncovered
+// intervals each time Add, Sub, TryAdd, AddDiff or SubDiff modifies the set.
+// The function is not invoked when an operation leaves the set unchanged.
+// Passing nil removes the function.
+func (p *IntervalSet[T]) OnChange(f func(added, removed *IntervalSet[T])) *IntervalSet[T] {
+	p.onChange = f
+	return p
+}
+
+func (p *IntervalSet[T]) notify(added, removed *IntervalSet[T]) {
+	if p.onChange != nil && (!added.IsEmpty() || !removed.IsEmpty()) {
+		p.onChange(added, removed)
+	}
+}
+
 func (p *IntervalSet[T]) sub(q Interval[T]) {
+	q, ok := p.prepare(q)
+	if !ok {
+		return
+	}
+
 	// the set is empty we do not need to remove the interval
 	if p.IsEmpty() {
 		return
@@ -221,10 +365,11 @@ func (p *IntervalSet[T]) sub(q Interval[T]) {
 }
 
 // Overlaps returns a new set containing the intervals overlapping q.
+// The new set has the options of p.
 func (p *IntervalSet[T]) Overlaps(q Interval[T]) *IntervalSet[T] {
 	l, h := p.rangeOfOverlap(q)
 
-	s := EmptySet[T]()
+	s := emptySetOf[T](p.config())
 
 	for _, v := range p.intervals[l:h] {
 		i := v.Intersect(q)
@@ -236,11 +381,15 @@ func (p *IntervalSet[T]) Overlaps(q Interval[T]) *IntervalSet[T] {
 	return s
 }
 
-// IsSubset reports whether s is a subset of p.
+// IsSubset reports whether s is a subset of p, comparing bounds with the tolerance of p.
 func (p *IntervalSet[T]) IsSubset(s *IntervalSet[T]) bool {
 	c := 0
 
 	for _, q := range s.intervals {
+		if p.tolerance > 0 {
+			q, _ = p.snap(q)
+		}
+
 		l, h := p.rangeOfOverlap(q)
 
 		for _, v := range p.intervals[l:h] {
@@ -255,17 +404,19 @@ func (p *IntervalSet[T]) IsSubset(s *IntervalSet[T]) bool {
 }
 
 // Complement returns a new set containing the intervals in q that are not in p.
+// The new set has the options of p, so the bounds are compared with its tolerance.
 func (p *IntervalSet[T]) Complement(q Interval[T]) *IntervalSet[T] {
 	l, h := p.rangeOfOverlap(q)
 
-	return EmptySet[T]().
+	return emptySetOf[T](p.config()).
 		Add(q).
 		Sub(p.intervals[l:h]...)
 }
 
 // Difference returns a new set containing the intervals in p that are not in q.
+// The new set has the options of p, so the bounds are compared with its tolerance.
 func (p *IntervalSet[T]) Difference(q *IntervalSet[T]) *IntervalSet[T] {
-	return EmptySet[T]().
+	return emptySetOf[T](p.config()).
 		Add(p.intervals...).
 		Sub(q.intervals...)
 }
@@ -320,8 +471,9 @@ func (p *IntervalSet[T]) rangeOfOverlap(q Interval[T]) (int, int) {
 }
 
 // Union returns a new set that is the union of the sets.
+// The new set has the options of the first set.
 func Union[T any](sets ...*IntervalSet[T]) *IntervalSet[T] {
-	s := EmptySet[T]()
+	s := emptySetLike(sets)
 	for _, set := range sets {
 		s.Add(set.intervals...)
 	}
@@ -329,8 +481,9 @@ func Union[T any](sets ...*IntervalSet[T]) *IntervalSet[T] {
 }
 
 // Intersection returns a new set that is the intersection of the sets.
+// The new set has the options of the first set.
 func Intersection[T any](sets ...*IntervalSet[T]) *IntervalSet[T] {
-	a := EmptySet[T]()
+	a := emptySetLike(sets)
 
 	for i, s := range sets {
 		// no need to go through the intervals of the last set
@@ -366,3 +519,141 @@ func Intersection[T any](sets ...*IntervalSet[T]) *IntervalSet[T] {
 
 	return a
 }
+
+// AtLeast returns a new set containing the portions covered by at least k of the sets.
+// AtLeast(1, ...) is equivalent to Union and AtLeast(len(sets), ...) to Intersection.
+// A k lower than 1 is treated as 1. The new set has the options of the first set.
+func AtLeast[T any](k int, sets ...*IntervalSet[T]) *IntervalSet[T] {
+	k = max(k, 1)
+	return sweep(sets, func(n int) bool { return n >= k })
+}
+
+// Exactly returns a new set containing the portions covered by exactly k of the sets.
+// A k lower than 1 returns an empty set since the portions covered by none of the sets are unbounded.
+// The new set has the options of the first set.
+func Exactly[T any](k int, sets ...*IntervalSet[T]) *IntervalSet[T] {
+	if k < 1 {
+		return emptySetLike(sets)
+	}
+	return sweep(sets, func(n int) bool { return n == k })
+}
+
+// endpoint is the minimum or the maximum value of an interval.
+type endpoint[T any] struct {
+	q     Interval[T]
+	start bool
+}
+
+func (e endpoint[T]) value() T {
+	if e.start {
+		return e.q.Min()
+	}
+	return e.q.Max()
+}
+
+// compare compares the value of e with the value of f.
+func (e endpoint[T]) compare(f endpoint[T]) int {
+	if e.start {
+		return compareMin(e.q, f.value())
+	}
+	return compareMax(e.q, f.value())
+}
+
+// sweep returns a new set containing the portions where the number of sets covering them
+// satisfies the predicate, which must not hold for 0. The intervals of a set never overlap,
+// the number of intervals covering a portion is therefore the number of sets covering it.
+//
+// The endpoints of all the intervals are sorted once and swept in order while counting the
+// intervals covering the current portion. Since T might not be ordered, the portions are cut
+// out of the union of the intervals with Punch and Intersect rather than built from their limits.
+func sweep[T any](sets []*IntervalSet[T], pred func(int) bool) *IntervalSet[T] {
+	n := 0
+	for _, s := range sets {
+		n += len(s.intervals)
+	}
+
+	endpoints := make([]endpoint[T], 0, 2*n)
+	for _, s := range sets {
+		for _, q := range s.intervals {
+			// empty intervals do not cover anything
+			if compareMin(q, q.Max()) == 0 {
+				continue
+			}
+			endpoints = append(endpoints, endpoint[T]{q: q, start: true}, endpoint[T]{q: q})
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].compare(endpoints[j]) < 0
+	})
+
+	r := emptySetLike(sets)
+
+	var (
+		union Interval[T] // interval of the union of the sets containing the current portion
+		first endpoint[T] // endpoint where the current portion satisfying the predicate starts
+	)
+
+	count := 0
+	for i := 0; i < len(endpoints); {
+		before := count
+		if before == 0 {
+			union = nil
+		}
+
+		// endpoints sharing the same value are processed together
+		e := endpoints[i]
+		for ; i < len(endpoints) && endpoints[i].compare(e) == 0; i++ {
+			if !endpoints[i].start {
+				count--
+				continue
+			}
+			count++
+			if union == nil {
+				union = endpoints[i].q
+			} else {
+				union = union.Encompass(endpoints[i].q)
+			}
+		}
+
+		switch {
+		case !pred(before) && pred(count):
+			first = e
+		case pred(before) && !pred(count):
+			// the sweep never leaves a portion and enters the next one at the same value,
+			// the intervals of r do not touch and can be appended in order
+			r.intervals = append(r.intervals, cut(union, first, e))
+		}
+	}
+
+	return r
+}
+
+// cut returns the portion of u between the values of the endpoints a and b,
+// u containing the intervals of both endpoints.
+func cut[T any](u Interval[T], a, b endpoint[T]) Interval[T] {
+	// l is the portion of u from a to the maximum of u
+	var l Interval[T]
+	if a.start {
+		if before, _ := u.Punch(a.q); before.IsZero() {
+			l = u
+		} else {
+			_, l = u.Punch(before)
+		}
+	} else {
+		_, l = u.Punch(a.q)
+	}
+
+	// h is the portion of u from the minimum of u to b
+	var h Interval[T]
+	if b.start {
+		h, _ = u.Punch(b.q)
+	} else {
+		if _, after := u.Punch(b.q); after.IsZero() {
+			h = u
+		} else {
+			h, _ = u.Punch(after)
+		}
+	}
+
+	return l.Intersect(h)
+}
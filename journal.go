@@ -0,0 +1,166 @@
+package intervalset
+
+import "errors"
+
+// ErrUnknownCheckpoint is returned when rolling back to a checkpoint that does not exist.
+var ErrUnknownCheckpoint = errors.New("intervalset: unknown checkpoint")
+
+// OpKind is the kind of an operation recorded by a Journal.
+type OpKind int
+
+const (
+	// OpAdd is an addition of intervals.
+	OpAdd OpKind = iota
+
+	// OpSub is a subtraction of intervals.
+	OpSub
+)
+
+// Operation is an operation recorded by a Journal.
+type Operation[T any] struct {
+	Kind      OpKind
+	Intervals []Interval[T]
+
+	// delta is the set of intervals the operation newly covered or uncovered.
+	delta *IntervalSet[T]
+}
+
+// NewJournal returns a new journal whose set initially contains the intervals of s
+// and is configured with the same options.
+func NewJournal[T any](s *IntervalSet[T]) *Journal[T] {
+	set := emptySetOf[T](s.config())
+	set.intervals = append(set.intervals, s.intervals...)
+
+	return &Journal[T]{
+		set:         set,
+		checkpoints: make(map[string]int),
+	}
+}
+
+// Journal is a set recording the additions and subtractions made to it,
+// so they can be undone, redone, rolled back to a checkpoint or replayed onto another set.
+type Journal[T any] struct {
+	set         *IntervalSet[T]
+	done        []Operation[T]
+	undone      []Operation[T]
+	checkpoints map[string]int
+}
+
+// Set returns the current set. The set is owned by the journal and must not be modified.
+func (j *Journal[T]) Set() *IntervalSet[T] {
+	return j.set
+}
+
+// Operations returns a copy of the operations applied to the set, the undone ones excluded.
+func (j *Journal[T]) Operations() []Operation[T] {
+	ops := make([]Operation[T], 0, len(j.done))
+	for _, op := range j.done {
+		ops = append(ops, Operation[T]{Kind: op.Kind, Intervals: append([]Interval[T](nil), op.Intervals...)})
+	}
+	return ops
+}
+
+// Add adds the given intervals to the set and records the operation.
+// It discards the operations that could be redone.
+func (j *Journal[T]) Add(intervals ...Interval[T]) *Journal[T] {
+	j.record(Operation[T]{Kind: OpAdd, Intervals: append([]Interval[T](nil), intervals...)})
+	return j
+}
+
+// Sub subtracts the given intervals from the set and records the operation.
+// It discards the operations that could be redone.
+func (j *Journal[T]) Sub(intervals ...Interval[T]) *Journal[T] {
+	j.record(Operation[T]{Kind: OpSub, Intervals: append([]Interval[T](nil), intervals...)})
+	return j
+}
+
+func (j *Journal[T]) record(op Operation[T]) {
+	j.undone = j.undone[:0]
+
+	// the checkpoints set after this point can no longer be reached
+	for name, n := range j.checkpoints {
+		if n > len(j.done) {
+			delete(j.checkpoints, name)
+		}
+	}
+
+	j.done = append(j.done, j.apply(op))
+}
+
+func (j *Journal[T]) apply(op Operation[T]) Operation[T] {
+	if op.Kind == OpAdd {
+		op.delta = j.set.AddDiff(op.Intervals...)
+	} else {
+		op.delta = j.set.SubDiff(op.Intervals...)
+	}
+	return op
+}
+
+// Undo reverts the last operation and reports whether there was an operation to undo.
+func (j *Journal[T]) Undo() bool {
+	if len(j.done) == 0 {
+		return false
+	}
+
+	op := j.done[len(j.done)-1]
+	j.done = j.done[:len(j.done)-1]
+
+	// the delta is exactly what the operation changed, reverting it restores the previous set
+	if op.Kind == OpAdd {
+		j.set.Sub(op.delta.intervals...)
+	} else {
+		j.set.Add(op.delta.intervals...)
+	}
+
+	j.undone = append(j.undone, op)
+	return true
+}
+
+// Redo applies again the last undone operation and reports whether there was an operation to redo.
+func (j *Journal[T]) Redo() bool {
+	if len(j.undone) == 0 {
+		return false
+	}
+
+	op := j.undone[len(j.undone)-1]
+	j.undone = j.undone[:len(j.undone)-1]
+
+	j.done = append(j.done, j.apply(op))
+	return true
+}
+
+// Checkpoint records the current state of the set under the given name,
+// replacing any checkpoint with the same name.
+func (j *Journal[T]) Checkpoint(name string) {
+	j.checkpoints[name] = len(j.done)
+}
+
+// Rollback undoes or redoes operations until the set is in the state recorded by the given checkpoint.
+// A checkpoint becomes unknown once the operations leading to it are discarded.
+func (j *Journal[T]) Rollback(name string) error {
+	n, ok := j.checkpoints[name]
+	if !ok {
+		return ErrUnknownCheckpoint
+	}
+
+	for len(j.done) > n {
+		j.Undo()
+	}
+	for len(j.done) < n {
+		j.Redo()
+	}
+
+	return nil
+}
+
+// Replay applies the operations of the journal to s and returns s.
+func (j *Journal[T]) Replay(s *IntervalSet[T]) *IntervalSet[T] {
+	for _, op := range j.done {
+		if op.Kind == OpAdd {
+			s.Add(op.Intervals...)
+		} else {
+			s.Sub(op.Intervals...)
+		}
+	}
+	return s
+}
@@ -0,0 +1,144 @@
+package intervalset
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestJournal_UndoRedo(t *testing.T) {
+	j := NewJournal(EmptySet[int]().Add(NewRange(1, 3)))
+
+	j.Add(NewRange(2, 6))
+	j.Sub(NewRange(4, 5))
+	j.Add(NewRange(8, 9))
+
+	var table = []struct {
+		f        func() bool
+		ok       bool
+		expected *IntervalSet[int]
+	}{
+		{j.Undo, true, genExpectedRangeSet([]Interval[int]{NewRange(1, 4), NewRange(5, 6)})},
+		{j.Undo, true, genExpectedRangeSet([]Interval[int]{NewRange(1, 6)})},
+		{j.Undo, true, genExpectedRangeSet([]Interval[int]{NewRange(1, 3)})},
+		{j.Undo, false, genExpectedRangeSet([]Interval[int]{NewRange(1, 3)})},
+		{j.Redo, true, genExpectedRangeSet([]Interval[int]{NewRange(1, 6)})},
+		{j.Redo, true, genExpectedRangeSet([]Interval[int]{NewRange(1, 4), NewRange(5, 6)})},
+		{j.Redo, true, genExpectedRangeSet([]Interval[int]{NewRange(1, 4), NewRange(5, 6), NewRange(8, 9)})},
+		{j.Redo, false, genExpectedRangeSet([]Interval[int]{NewRange(1, 4), NewRange(5, 6), NewRange(8, 9)})},
+	}
+
+	for i, tc := range table {
+		if ok := tc.f(); ok != tc.ok {
+			t.Errorf("test case %d: expected %t, got %t", i, tc.ok, ok)
+		}
+		if !j.Set().Equal(tc.expected) {
+			t.Errorf("test case %d: both sets should be equal, expected %v, got %v", i, tc.expected, j.Set().AsSlice())
+		}
+	}
+
+	// a new operation discards the operations that could be redone
+	j.Undo()
+	j.Sub(NewRange(1, 2))
+	if j.Redo() {
+		t.Errorf("expected nothing to redo")
+	}
+	if n := len(j.Operations()); n != 3 {
+		t.Errorf("expected 3 operations, got %d", n)
+	}
+}
+
+func TestJournal_Rollback(t *testing.T) {
+	j := NewJournal(EmptySet[int]())
+
+	j.Add(NewRange(1, 5))
+	j.Checkpoint("first")
+	j.Sub(NewRange(2, 3))
+	j.Checkpoint("second")
+	j.Add(NewRange(7, 9))
+
+	if err := j.Rollback("first"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expected := genExpectedRangeSet([]Interval[int]{NewRange(1, 5)})
+	if !j.Set().Equal(expected) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, j.Set().AsSlice())
+	}
+
+	// checkpoints ahead of the current state can be reached as long as the history is kept
+	if err := j.Rollback("second"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expected = genExpectedRangeSet([]Interval[int]{NewRange(1, 2), NewRange(3, 5)})
+	if !j.Set().Equal(expected) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, j.Set().AsSlice())
+	}
+
+	j.Rollback("first")
+	j.Add(NewRange(10, 12))
+
+	if err := j.Rollback("second"); !errors.Is(err, ErrUnknownCheckpoint) {
+		t.Errorf("expected %v, got %v", ErrUnknownCheckpoint, err)
+	}
+	if err := j.Rollback("first"); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := j.Rollback("unknown"); !errors.Is(err, ErrUnknownCheckpoint) {
+		t.Errorf("expected %v, got %v", ErrUnknownCheckpoint, err)
+	}
+}
+
+func TestJournal_Replay(t *testing.T) {
+	j := NewJournal(EmptySet[int]())
+
+	j.Add(NewRange(1, 5), NewRange(8, 10))
+	j.Sub(NewRange(3, 9))
+	j.Add(NewRange(20, 30))
+	j.Undo()
+
+	s := j.Replay(EmptySet[int]().Add(NewRange(4, 6), NewRange(12, 13)))
+
+	expected := genExpectedRangeSet([]Interval[int]{NewRange(1, 3), NewRange(9, 10), NewRange(12, 13)})
+	if !s.Equal(expected) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, s.AsSlice())
+	}
+}
+
+func TestJournal_OperationsAreCopied(t *testing.T) {
+	j := NewJournal(EmptySet[int]())
+
+	qs := []Interval[int]{NewRange(1, 5), NewRange(8, 10)}
+	j.Add(qs...)
+
+	// neither the caller's slice nor the returned operations share the recorded intervals
+	qs[0] = NewRange(100, 200)
+	j.Operations()[0].Intervals[1] = NewRange(300, 400)
+
+	s := j.Replay(EmptySet[int]())
+
+	expected := genExpectedRangeSet([]Interval[int]{NewRange(1, 5), NewRange(8, 10)})
+	if !s.Equal(expected) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, s.AsSlice())
+	}
+}
+
+func ExampleJournal() {
+	j := NewJournal(EmptySet[int]())
+
+	j.Add(NewRange(1, 5))
+	j.Checkpoint("draft")
+	j.Sub(NewRange(2, 3))
+	fmt.Println(j.Set().AsSlice())
+
+	j.Undo()
+	fmt.Println(j.Set().AsSlice())
+
+	j.Redo()
+	j.Rollback("draft")
+	fmt.Println(j.Set().AsSlice())
+
+	// Output:
+	// [{1 2} {3 5}]
+	// [{1 5}]
+	// [{1 5}]
+}
@@ -0,0 +1,25 @@
+package intervalset
+
+import "time"
+
+// Length returns the sum of the lengths of the ranges in the set,
+// which is infinite when a range is unbounded.
+func Length[T Number](s *IntervalSet[T]) T {
+	var l T
+	for _, v := range s.intervals {
+		// empty ranges are skipped since the length of [+Inf, +Inf] would be NaN
+		if v.Max() > v.Min() {
+			l += v.Max() - v.Min()
+		}
+	}
+	return l
+}
+
+// Duration returns the sum of the durations of the periods in the set.
+func Duration(s *IntervalSet[time.Time]) time.Duration {
+	var d time.Duration
+	for _, v := range s.intervals {
+		d += v.Max().Sub(v.Min())
+	}
+	return d
+}
@@ -0,0 +1,42 @@
+package intervalset
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLength(t *testing.T) {
+	if l := Length(EmptySet[int]()); l != 0 {
+		t.Errorf("expected an empty set to have a length of 0, got %d", l)
+	}
+
+	s := EmptySet[float64]().Add(
+		NewRange(1.5, 3),
+		NewRange(5, 7.25),
+	)
+
+	if l := Length(s); l != 3.75 {
+		t.Errorf("expected a length of 3.75, got %f", l)
+	}
+}
+
+func TestDuration(t *testing.T) {
+	if d := Duration(EmptySet[time.Time]()); d != 0 {
+		t.Errorf("expected an empty set to have a duration of 0, got %s", d)
+	}
+
+	s := EmptySet[time.Time]().Add(
+		NewPeriod(
+			time.Date(2023, time.December, 1, 8, 0, 0, 0, time.UTC),
+			time.Date(2023, time.December, 1, 12, 0, 0, 0, time.UTC),
+		),
+		NewPeriod(
+			time.Date(2023, time.December, 1, 13, 0, 0, 0, time.UTC),
+			time.Date(2023, time.December, 1, 13, 30, 0, 0, time.UTC),
+		),
+	)
+
+	if d := Duration(s); d != 4*time.Hour+30*time.Minute {
+		t.Errorf("expected a duration of 4h30m, got %s", d)
+	}
+}
@@ -1,6 +1,7 @@
 package intervalset
 
 import (
+	"fmt"
 	"time"
 
 	limit "github.com/mickaelvieira/intervalset/internal/time"
@@ -11,6 +12,19 @@ func NewPeriod(s, e time.Time) Period[time.Time] {
 	return Period[time.Time]{start: s, end: e}
 }
 
+// MakePeriod returns a new period with the given start and end dates,
+// or an error when the start date is after the end date or when both dates are in different locations.
+func MakePeriod(s, e time.Time) (Period[time.Time], error) {
+	p := NewPeriod(s, e)
+	if err := p.validate(); err != nil {
+		return p, err
+	}
+	if s.Location().String() != e.Location().String() {
+		return p, fmt.Errorf("%w: %s and %s", ErrLocationMismatch, s.Location(), e.Location())
+	}
+	return p, nil
+}
+
 // Period represents a portion of time.
 type Period[T time.Time] struct {
 	start T
@@ -44,6 +58,21 @@ func (p Period[T]) IsEmpty() bool {
 	return time.Time(p.start).Equal(time.Time(p.end))
 }
 
+func (p Period[T]) validate() error {
+	if !p.IsValid() {
+		return fmt.Errorf("%w: [%s, %s]", ErrInvertedBounds, time.Time(p.start), time.Time(p.end))
+	}
+	return nil
+}
+
+// normalize returns the period with its dates in order.
+func (p Period[T]) normalize() (Interval[T], bool) {
+	if !p.IsValid() {
+		return Period[T]{start: p.end, end: p.start}, true
+	}
+	return p, true
+}
+
 // Equal reports whether p is equal to q.
 // Two periods are equal when their start & end dates are equal.
 func (p Period[T]) Equal(q Interval[T]) bool {
@@ -77,6 +106,58 @@ func (p Period[T]) Contains(q Interval[T]) bool {
 		(e.Before(time.Time(p.end)) || e.Equal(time.Time(p.end)))
 }
 
+// Meets reports whether p ends exactly when q starts.
+func (p Period[T]) Meets(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenMeets
+}
+
+// MetBy reports whether p starts exactly when q ends.
+func (p Period[T]) MetBy(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenMetBy
+}
+
+// StrictlyOverlaps reports whether p starts before q and ends during q.
+// Unlike Overlaps, it does not hold when p meets, contains or is contained by q.
+func (p Period[T]) StrictlyOverlaps(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenOverlaps
+}
+
+// OverlappedBy reports whether p starts during q and ends after q.
+func (p Period[T]) OverlappedBy(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenOverlappedBy
+}
+
+// Starts reports whether p and q start together and p ends before q.
+func (p Period[T]) Starts(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenStarts
+}
+
+// StartedBy reports whether p and q start together and p ends after q.
+func (p Period[T]) StartedBy(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenStartedBy
+}
+
+// During reports whether p starts after q and ends before q.
+func (p Period[T]) During(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenDuring
+}
+
+// StrictlyContains reports whether p starts before q and ends after q.
+// Unlike Contains, it does not hold when p and q share a limit.
+func (p Period[T]) StrictlyContains(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenContains
+}
+
+// Finishes reports whether p and q end together and p starts after q.
+func (p Period[T]) Finishes(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenFinishes
+}
+
+// FinishedBy reports whether p and q end together and p starts before q.
+func (p Period[T]) FinishedBy(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenFinishedBy
+}
+
 // Intersect returns a new period representing the intersection of both periods.
 // The new period is either a valid and non-empty period (its start date being strictly
 // greater than its end date) or a zero value period.
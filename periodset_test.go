@@ -1889,3 +1889,34 @@ func TestPeriodSet_IterBetween(t *testing.T) {
 		t.Errorf("expected both sets to be equal, s3 %+v, e3 %+v", s3, e3)
 	}
 }
+
+func TestAtLeast_period(t *testing.T) {
+	at := func(h int) time.Time {
+		return time.Date(2023, time.December, 1, h, 0, 0, 0, time.UTC)
+	}
+
+	// availability of five team members
+	sets := []*IntervalSet[time.Time]{
+		EmptySet[time.Time]().Add(NewPeriod(at(9), at(12)), NewPeriod(at(14), at(17))),
+		EmptySet[time.Time]().Add(NewPeriod(at(10), at(13))),
+		EmptySet[time.Time]().Add(NewPeriod(at(8), at(10)), NewPeriod(at(15), at(18))),
+		EmptySet[time.Time]().Add(NewPeriod(at(11), at(16))),
+		EmptySet[time.Time]().Add(NewPeriod(at(9), at(11)), NewPeriod(at(16), at(17))),
+	}
+
+	got := AtLeast(3, sets...)
+
+	expected := genExpectedPeriodSet([]Interval[time.Time]{
+		NewPeriod(at(9), at(12)),
+		NewPeriod(at(15), at(17)),
+	})
+
+	if !got.Equal(expected) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, got.AsSlice())
+	}
+
+	got = Exactly(5, sets...)
+	if !got.IsEmpty() {
+		t.Errorf("expected an empty set, got %v", got.AsSlice())
+	}
+}
@@ -0,0 +1,368 @@
+package intervalset
+
+// EmptyPersistentSet returns an empty persistent set.
+func EmptyPersistentSet[T any]() *PersistentSet[T] {
+	return &PersistentSet[T]{}
+}
+
+// PersistentSet is an immutable ordered set of intervals.
+//
+// Unlike IntervalSet, its operations never modify the set but return a new set sharing
+// most of its structure with the original one. A set can therefore be shared between
+// goroutines without synchronization and keeping a reference to a set is enough to take
+// a snapshot. The intervals are stored in a persistent balanced tree, so adding or
+// subtracting an interval only copies O(log n) nodes plus the intervals it overlaps.
+//
+// A persistent set keeps the options of the IntervalSet it was created from, such as its
+// tolerance, so Mutable restores them, but its own operations do not apply them.
+type PersistentSet[T any] struct {
+	root   *node[T]
+	config setConfig
+}
+
+// Persistent returns a new persistent set containing the intervals of the set.
+func (p *IntervalSet[T]) Persistent() *PersistentSet[T] {
+	return &PersistentSet[T]{root: build(p.intervals), config: p.config()}
+}
+
+// Mutable returns a new IntervalSet containing the intervals of the set,
+// configured with the options of the set it was created from.
+func (p *PersistentSet[T]) Mutable() *IntervalSet[T] {
+	s := emptySetOf[T](p.config)
+	s.intervals = p.AsSlice()
+	return s
+}
+
+// AsSlice returns a new slice containing the intervals of the set.
+func (p *PersistentSet[T]) AsSlice() []Interval[T] {
+	s := make([]Interval[T], 0, p.Len())
+	p.Iter(func(i Interval[T]) bool {
+		s = append(s, i)
+		return true
+	})
+	return s
+}
+
+// Len returns the number of intervals in the set.
+func (p *PersistentSet[T]) Len() int {
+	return p.root.len()
+}
+
+// IsEmpty reports whether the set is empty.
+func (p *PersistentSet[T]) IsEmpty() bool {
+	return p.root == nil
+}
+
+// Equal reports whether the set is equal to another set.
+func (p *PersistentSet[T]) Equal(q *PersistentSet[T]) bool {
+	if p.root == q.root {
+		return true
+	}
+	a, b := p.AsSlice(), q.AsSlice()
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if !v.Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Add returns a new set containing the intervals of the set and the given intervals.
+// Intervals will be merged with the intervals present in the set, invalid and empty intervals are ignored.
+func (p *PersistentSet[T]) Add(intervals ...Interval[T]) *PersistentSet[T] {
+	root := p.root
+	for _, q := range intervals {
+		if _, ok := sanitize(SkipInvalid, q); !ok || isEmptyInterval(q) {
+			continue
+		}
+
+		l, m, r := splitAround(root, q)
+
+		interval := q
+		m.each(func(v Interval[T]) bool {
+			if e := interval.Encompass(v); !e.IsZero() {
+				interval = e
+			}
+			return true
+		})
+
+		root = join(l, interval, r)
+	}
+	return &PersistentSet[T]{root: root, config: p.config}
+}
+
+// Sub returns a new set containing the intervals of the set minus the given intervals.
+// Invalid and empty intervals are ignored.
+func (p *PersistentSet[T]) Sub(intervals ...Interval[T]) *PersistentSet[T] {
+	root := p.root
+	for _, q := range intervals {
+		if _, ok := sanitize(SkipInvalid, q); !ok || isEmptyInterval(q) {
+			continue
+		}
+
+		l, m, r := splitAround(root, q)
+
+		m.each(func(v Interval[T]) bool {
+			a, b := v.Punch(q)
+			if !a.IsZero() {
+				l = join(l, a, nil)
+			}
+			if !b.IsZero() {
+				l = join(l, b, nil)
+			}
+			return true
+		})
+
+		root = join2(l, r)
+	}
+	return &PersistentSet[T]{root: root, config: p.config}
+}
+
+// Overlaps returns a new set containing the intervals overlapping q.
+func (p *PersistentSet[T]) Overlaps(q Interval[T]) *PersistentSet[T] {
+	var root *node[T]
+	p.IterBetween(q, func(i Interval[T]) bool {
+		root = join(root, i, nil)
+		return true
+	})
+	return &PersistentSet[T]{root: root, config: p.config}
+}
+
+// Union returns a new set that is the union of both sets.
+// The intervals of the smaller set are added to the larger one, whose structure is shared.
+func (p *PersistentSet[T]) Union(q *PersistentSet[T]) *PersistentSet[T] {
+	if p.Len() < q.Len() {
+		u := q.Add(p.AsSlice()...)
+		u.config = p.config
+		return u
+	}
+	return p.Add(q.AsSlice()...)
+}
+
+// Intersection returns a new set that is the intersection of both sets.
+func (p *PersistentSet[T]) Intersection(q *PersistentSet[T]) *PersistentSet[T] {
+	config := p.config
+	if p.Len() < q.Len() {
+		p, q = q, p
+	}
+
+	// the intervals of q do not touch, the portions of p overlapping them can be appended in order
+	var root *node[T]
+	q.Iter(func(v Interval[T]) bool {
+		p.IterBetween(v, func(i Interval[T]) bool {
+			root = join(root, i, nil)
+			return true
+		})
+		return true
+	})
+	return &PersistentSet[T]{root: root, config: config}
+}
+
+// Difference returns a new set containing the intervals of the set minus the intervals of q.
+func (p *PersistentSet[T]) Difference(q *PersistentSet[T]) *PersistentSet[T] {
+	return p.Sub(q.AsSlice()...)
+}
+
+// Complement returns a new set containing the intervals in q that are not in p.
+func (p *PersistentSet[T]) Complement(q Interval[T]) *PersistentSet[T] {
+	c := (&PersistentSet[T]{config: p.config}).Add(q)
+	p.IterBetween(q, func(i Interval[T]) bool {
+		c = c.Sub(i)
+		return true
+	})
+	return c
+}
+
+// IsSubset reports whether s is a subset of p.
+func (p *PersistentSet[T]) IsSubset(s *PersistentSet[T]) bool {
+	return s.root.each(func(v Interval[T]) bool {
+		i := p.root.first(func(i Interval[T]) bool {
+			return !i.Before(v)
+		})
+		return i != nil && i.Contains(v)
+	})
+}
+
+// Iter iterates over the set and pass intervals to the anonymous function.
+// It stops when the function returns false or when there are no more intervals to consume.
+func (p *PersistentSet[T]) Iter(f func(Interval[T]) bool) {
+	p.root.each(f)
+}
+
+// IterBetween iterates over the set between the given interval.
+// Each interval is truncated to fit within q and pass intervals to the anonymous function.
+// It stops when the function returns false or when there are no more intervals to consume.
+func (p *PersistentSet[T]) IterBetween(q Interval[T], f func(Interval[T]) bool) {
+	_, m, _ := splitAround(p.root, q)
+	m.each(func(v Interval[T]) bool {
+		i := v.Intersect(q)
+		if i.IsZero() {
+			return true
+		}
+		return f(i)
+	})
+}
+
+// node is a node of a persistent AVL tree. Nodes are never modified once created.
+type node[T any] struct {
+	interval    Interval[T]
+	left, right *node[T]
+	height      int
+	size        int
+}
+
+func (n *node[T]) len() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *node[T]) depth() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// each walks the tree in order and stops when f returns false.
+func (n *node[T]) each(f func(Interval[T]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.left.each(f) && f(n.interval) && n.right.each(f)
+}
+
+// first returns the first interval for which f returns true, nil when there is none.
+// f must return false for a prefix of the ordered intervals and true for the others.
+func (n *node[T]) first(f func(Interval[T]) bool) Interval[T] {
+	var found Interval[T]
+	for n != nil {
+		if f(n.interval) {
+			found, n = n.interval, n.left
+		} else {
+			n = n.right
+		}
+	}
+	return found
+}
+
+func newNode[T any](l *node[T], v Interval[T], r *node[T]) *node[T] {
+	return &node[T]{
+		interval: v,
+		left:     l,
+		right:    r,
+		height:   max(l.depth(), r.depth()) + 1,
+		size:     l.len() + r.len() + 1,
+	}
+}
+
+// build returns a balanced tree of the ordered intervals.
+func build[T any](intervals []Interval[T]) *node[T] {
+	if len(intervals) == 0 {
+		return nil
+	}
+	m := len(intervals) / 2
+	return newNode(build(intervals[:m]), intervals[m], build(intervals[m+1:]))
+}
+
+func rotateLeft[T any](n *node[T]) *node[T] {
+	r := n.right
+	return newNode(newNode(n.left, n.interval, r.left), r.interval, r.right)
+}
+
+func rotateRight[T any](n *node[T]) *node[T] {
+	l := n.left
+	return newNode(l.left, l.interval, newNode(l.right, n.interval, n.right))
+}
+
+// join returns a balanced tree containing the intervals of l, v and the intervals of r,
+// all the intervals of l being positioned before v and all the intervals of r after v.
+func join[T any](l *node[T], v Interval[T], r *node[T]) *node[T] {
+	switch {
+	case l.depth() > r.depth()+1:
+		return joinRight(l, v, r)
+	case r.depth() > l.depth()+1:
+		return joinLeft(l, v, r)
+	}
+	return newNode(l, v, r)
+}
+
+func joinRight[T any](l *node[T], v Interval[T], r *node[T]) *node[T] {
+	if l.right.depth() <= r.depth()+1 {
+		t := newNode(l.right, v, r)
+		if t.depth() <= l.left.depth()+1 {
+			return newNode(l.left, l.interval, t)
+		}
+		return rotateLeft(newNode(l.left, l.interval, rotateRight(t)))
+	}
+
+	t := joinRight(l.right, v, r)
+	n := newNode(l.left, l.interval, t)
+	if t.depth() <= l.left.depth()+1 {
+		return n
+	}
+	return rotateLeft(n)
+}
+
+func joinLeft[T any](l *node[T], v Interval[T], r *node[T]) *node[T] {
+	if r.left.depth() <= l.depth()+1 {
+		t := newNode(l, v, r.left)
+		if t.depth() <= r.right.depth()+1 {
+			return newNode(t, r.interval, r.right)
+		}
+		return rotateRight(newNode(rotateLeft(t), r.interval, r.right))
+	}
+
+	t := joinLeft(l, v, r.left)
+	n := newNode(t, r.interval, r.right)
+	if t.depth() <= r.right.depth()+1 {
+		return n
+	}
+	return rotateRight(n)
+}
+
+// join2 returns a balanced tree containing the intervals of l followed by the intervals of r.
+func join2[T any](l, r *node[T]) *node[T] {
+	if l == nil {
+		return r
+	}
+	rest, last := splitLast(l)
+	return join(rest, last, r)
+}
+
+func splitLast[T any](n *node[T]) (*node[T], Interval[T]) {
+	if n.right == nil {
+		return n.left, n.interval
+	}
+	r, last := splitLast(n.right)
+	return join(n.left, n.interval, r), last
+}
+
+// split splits the tree in two: the intervals for which left returns true and the others.
+// left must return true for a prefix of the ordered intervals.
+func split[T any](n *node[T], left func(Interval[T]) bool) (*node[T], *node[T]) {
+	if n == nil {
+		return nil, nil
+	}
+	if left(n.interval) {
+		l, r := split(n.right, left)
+		return join(n.left, n.interval, l), r
+	}
+	l, r := split(n.left, left)
+	return l, join(r, n.interval, n.right)
+}
+
+// splitAround splits the tree in three: the intervals before q, the ones overlapping q and the ones after q.
+func splitAround[T any](n *node[T], q Interval[T]) (*node[T], *node[T], *node[T]) {
+	l, rest := split(n, func(v Interval[T]) bool {
+		return v.Before(q)
+	})
+	m, r := split(rest, func(v Interval[T]) bool {
+		return !v.After(q)
+	})
+	return l, m, r
+}
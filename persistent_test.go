@@ -0,0 +1,208 @@
+package intervalset
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// checkBalanced reports whether the tree is a valid AVL tree.
+func checkBalanced[T any](n *node[T]) bool {
+	if n == nil {
+		return true
+	}
+	d := n.left.depth() - n.right.depth()
+	return d >= -1 && d <= 1 &&
+		n.height == max(n.left.depth(), n.right.depth())+1 &&
+		n.size == n.left.len()+n.right.len()+1 &&
+		checkBalanced(n.left) && checkBalanced(n.right)
+}
+
+func TestPersistentSet_AddAndSub(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+
+	s := EmptySet[int]()
+	p := EmptyPersistentSet[int]()
+
+	for i := 0; i < 2000; i++ {
+		l := r.Intn(1000)
+		q := NewRange(l, l+1+r.Intn(20))
+
+		if r.Intn(3) == 0 {
+			s.Sub(q)
+			p = p.Sub(q)
+		} else {
+			s.Add(q)
+			p = p.Add(q)
+		}
+
+		if !p.Mutable().Equal(s) {
+			t.Fatalf("both sets should be equal after %d operations, expected %v, got %v", i, s.AsSlice(), p.AsSlice())
+		}
+		if !checkBalanced(p.root) {
+			t.Fatalf("expected the tree to be balanced after %d operations", i)
+		}
+	}
+
+	if p.Len() != len(s.AsSlice()) {
+		t.Errorf("expected %d intervals, got %d", len(s.AsSlice()), p.Len())
+	}
+}
+
+func TestPersistentSet_Immutable(t *testing.T) {
+	/*----------------------------------------------
+	|  T  | 1   2   3   4   5   6   7   8   9   10 |
+	| s1  |     |---|       |---|   |---|          |
+	| (+) |         |-------|                      |
+	| s2  |     |---------------|   |---|          |
+	| (-) |             |---|                      |
+	| s3  |     |-------|   |---|   |---|          |
+	----------------------------------------------*/
+	s1 := EmptySet[int]().Add(NewRange(2, 3), NewRange(5, 6), NewRange(7, 8)).Persistent()
+	s2 := s1.Add(NewRange(3, 5))
+	s3 := s2.Sub(NewRange(4, 5))
+
+	var table = []struct {
+		s *PersistentSet[int]
+		e *IntervalSet[int]
+	}{
+		{s1, genExpectedRangeSet([]Interval[int]{NewRange(2, 3), NewRange(5, 6), NewRange(7, 8)})},
+		{s2, genExpectedRangeSet([]Interval[int]{NewRange(2, 6), NewRange(7, 8)})},
+		{s3, genExpectedRangeSet([]Interval[int]{NewRange(2, 4), NewRange(5, 6), NewRange(7, 8)})},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			if !tc.s.Mutable().Equal(tc.e) {
+				t.Errorf("both sets should be equal, expected %v, got %v", tc.e, tc.s.AsSlice())
+			}
+		})
+	}
+
+	if s1.Equal(s2) {
+		t.Errorf("expected s1 and s2 to differ")
+	}
+	if !s1.Equal(s1.Add()) {
+		t.Errorf("expected s1 to be equal to itself")
+	}
+}
+
+func TestPersistentSet_Overlaps(t *testing.T) {
+	p := EmptyPersistentSet[int]().Add(
+		NewRange(1, 3),
+		NewRange(5, 7),
+		NewRange(9, 12),
+	)
+
+	got := p.Overlaps(NewRange(2, 10))
+
+	expected := genExpectedRangeSet([]Interval[int]{
+		NewRange(2, 3),
+		NewRange(5, 7),
+		NewRange(9, 10),
+	})
+
+	if !got.Mutable().Equal(expected) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, got.AsSlice())
+	}
+
+	c := 0
+	p.IterBetween(NewRange(2, 10), func(Interval[int]) bool {
+		c++
+		return c < 2
+	})
+	if c != 2 {
+		t.Errorf("expected the iteration to stop after 2 intervals, got %d", c)
+	}
+
+	if !EmptyPersistentSet[int]().Add(NewRange(4, 4)).IsEmpty() || p.IsEmpty() {
+		t.Errorf("unexpected emptiness")
+	}
+}
+
+func TestPersistentSet_SetOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+
+	gen := func() *IntervalSet[int] {
+		s := EmptySet[int]()
+		for i := 0; i < r.Intn(30); i++ {
+			l := r.Intn(300)
+			s.Add(NewRange(l, l+1+r.Intn(20)))
+		}
+		return s
+	}
+
+	for i := 0; i < 200; i++ {
+		a, b := gen(), gen()
+		pa, pb := a.Persistent(), b.Persistent()
+		w := NewRange(r.Intn(150), 150+r.Intn(150))
+
+		var table = []struct {
+			got      *PersistentSet[int]
+			expected *IntervalSet[int]
+		}{
+			{pa.Union(pb), Union(a, b)},
+			{pa.Intersection(pb), a.Difference(a.Difference(b))},
+			{pa.Difference(pb), a.Difference(b)},
+			{pa.Complement(w), a.Complement(w)},
+		}
+
+		for j, tc := range table {
+			if !tc.got.Mutable().Equal(tc.expected) {
+				t.Fatalf("operation %d on %v and %v: expected %v, got %v", j, a.AsSlice(), b.AsSlice(), tc.expected.AsSlice(), tc.got.AsSlice())
+			}
+			if !checkBalanced(tc.got.root) {
+				t.Fatalf("operation %d: expected the tree to be balanced", j)
+			}
+		}
+
+		if got, expected := pa.IsSubset(pb), a.IsSubset(b); got != expected {
+			t.Fatalf("IsSubset of %v and %v: expected %t, got %t", a.AsSlice(), b.AsSlice(), expected, got)
+		}
+		if !pa.IsSubset(pa.Intersection(pb)) || !pa.Union(pb).IsSubset(pa) {
+			t.Fatalf("expected the intersection and the set to be subsets of %v and %v", a.AsSlice(), b.AsSlice())
+		}
+
+		// the operands are left untouched
+		if !pa.Mutable().Equal(a) || !pb.Mutable().Equal(b) {
+			t.Fatalf("expected the operands to be left untouched")
+		}
+	}
+}
+
+func TestPersistentSet_ConcurrentReads(t *testing.T) {
+	p := EmptyPersistentSet[int]()
+	for i := 0; i < 100; i++ {
+		p = p.Add(NewRange(i*10, i*10+5))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// each goroutine derives its own versions from the shared set
+			q := p.Sub(NewRange(i*10, i*10+100))
+			if q.Len() >= p.Len() {
+				t.Errorf("expected intervals to be removed")
+			}
+			if p.Len() != 100 {
+				t.Errorf("expected the shared set to be left untouched")
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func ExamplePersistentSet() {
+	s1 := EmptyPersistentSet[int]().Add(NewRange(1, 3), NewRange(5, 7))
+	s2 := s1.Add(NewRange(3, 5))
+
+	fmt.Println(s1.AsSlice())
+	fmt.Println(s2.AsSlice())
+
+	// Output:
+	// [{1 3} {5 7}]
+	// [{1 7}]
+}
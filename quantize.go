@@ -0,0 +1,184 @@
+package intervalset
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"time"
+)
+
+// QuantizeMode defines how Quantize rounds the bounds of an interval to the grid.
+type QuantizeMode int
+
+const (
+	// QuantizeOutward rounds the lower bound down and the upper bound up, the interval can only grow.
+	QuantizeOutward QuantizeMode = iota
+
+	// QuantizeInward rounds the lower bound up and the upper bound down, the interval can only shrink
+	// and becomes empty when it does not contain a whole step of the grid.
+	QuantizeInward
+
+	// QuantizeNearest rounds both bounds to the nearest point of the grid, halfway values being rounded up.
+	QuantizeNearest
+)
+
+// Quantize returns the range with its bounds rounded to multiples of step according to the mode.
+// The range is returned as is when step is not positive.
+func (p Range[T]) Quantize(step T, mode QuantizeMode) Range[T] {
+	l, u := quantizeBounds(p.lower, p.upper, 0, step, mode)
+	return Range[T]{lower: l, upper: u}
+}
+
+// Quantize returns the period with its dates rounded to the grid made of the instants
+// origin + k * step according to the mode. The origin sets the alignment of the grid,
+// e.g. the midnight of a day in a given location to round periods to whole days in that
+// location. The dates of the period are returned in the location of the origin.
+// The period is returned as is when step is not positive.
+func (p Period[T]) Quantize(step time.Duration, mode QuantizeMode, origin time.Time) Period[T] {
+	l, u := quantizeBounds(time.Time(p.start), time.Time(p.end), origin, step, mode)
+	return Period[T]{start: T(l), end: T(u)}
+}
+
+// Quantize returns a new set where the bounds of the intervals are rounded to the grid
+// made of the values origin + k * step according to the mode, step being a number for
+// ranges or a time.Duration for periods. Intervals becoming adjacent are merged and
+// intervals becoming empty are dropped. The new set has the options of s.
+func Quantize[T any, G Number](s *IntervalSet[T], step G, mode QuantizeMode, origin T) *IntervalSet[T] {
+	q := emptySetOf[T](s.config())
+	for _, v := range s.intervals {
+		l, u := quantizeBounds(v.Min(), v.Max(), origin, step, mode)
+		if isEmpty(l, u) {
+			continue
+		}
+		if i, ok := newInterval(l, u); ok {
+			q.Add(i)
+		} else {
+			q.Add(v)
+		}
+	}
+	return q
+}
+
+// quantizeBounds rounds the bounds to the grid according to the mode.
+// Inverted bounds resulting from an inward rounding are collapsed into an empty interval.
+func quantizeBounds[T any, G Number](l, u, origin T, step G, mode QuantizeMode) (T, T) {
+	if step <= 0 {
+		return l, u
+	}
+
+	switch mode {
+	case QuantizeInward:
+		l, u = roundToGrid(l, origin, step, roundUp), roundToGrid(u, origin, step, roundDown)
+		if distance(l, u) < 0 {
+			u = l
+		}
+	case QuantizeNearest:
+		l, u = roundToGrid(l, origin, step, roundNearest), roundToGrid(u, origin, step, roundNearest)
+	default:
+		l, u = roundToGrid(l, origin, step, roundDown), roundToGrid(u, origin, step, roundUp)
+	}
+	return l, u
+}
+
+// rounding is the direction in which a value is rounded to the grid.
+type rounding int
+
+const (
+	roundDown rounding = iota
+	roundUp
+	roundNearest
+)
+
+// remainder returns the value to subtract from a value at the distance d from the origin
+// of the grid to round it in the direction r, step being positive. The distance is a big
+// integer since it may not fit in the type of the values.
+func (r rounding) remainder(d *big.Int, step int64) *big.Int {
+	s := big.NewInt(step)
+
+	// the remainder of a Euclidean division is never negative
+	rem := new(big.Int).Mod(d, s)
+	if rem.Sign() != 0 && (r == roundUp || (r == roundNearest && rem.Cmp(new(big.Int).Sub(s, rem)) >= 0)) {
+		rem.Sub(rem, s)
+	}
+	return rem
+}
+
+// round returns f rounded in the direction r.
+func (r rounding) round(f float64) float64 {
+	switch r {
+	case roundDown:
+		return math.Floor(f)
+	case roundUp:
+		return math.Ceil(f)
+	}
+	return math.Floor(f + 0.5)
+}
+
+// roundToGrid returns v rounded in the direction r to the grid made of the values origin + k * step.
+// Values that cannot be rounded are returned as they are, as well as the infinities and the minimum
+// and maximum values of integer types that stand for unbounded limits. Integers rounded beyond the
+// limits of their type saturate instead of wrapping around.
+func roundToGrid[T any, G Number](v, origin T, step G, r rounding) T {
+	lo, hi := unbounded[T]()
+	if isEqual(v, lo) || isEqual(v, hi) {
+		return v
+	}
+
+	if t, ok := any(v).(time.Time); ok {
+		return any(roundTime(t, any(origin).(time.Time), time.Duration(step), r)).(T)
+	}
+
+	var q T
+	x, o := reflect.ValueOf(v), reflect.ValueOf(origin)
+	qv := reflect.ValueOf(&q).Elem()
+
+	switch x.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if int64(step) == 0 {
+			return v
+		}
+		d := new(big.Int).Sub(big.NewInt(x.Int()), big.NewInt(o.Int()))
+		n := new(big.Int).Sub(big.NewInt(x.Int()), r.remainder(d, int64(step)))
+		switch {
+		case n.Cmp(big.NewInt(reflect.ValueOf(lo).Int())) < 0:
+			return lo
+		case n.Cmp(big.NewInt(reflect.ValueOf(hi).Int())) > 0:
+			return hi
+		default:
+			qv.SetInt(n.Int64())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if int64(step) == 0 {
+			return v
+		}
+		xb := new(big.Int).SetUint64(x.Uint())
+		d := new(big.Int).Sub(xb, new(big.Int).SetUint64(o.Uint()))
+		n := xb.Sub(xb, r.remainder(d, int64(step)))
+		switch {
+		case n.Sign() < 0:
+			return lo
+		case n.Cmp(new(big.Int).SetUint64(reflect.ValueOf(hi).Uint())) > 0:
+			return hi
+		default:
+			qv.SetUint(n.Uint64())
+		}
+	case reflect.Float32, reflect.Float64:
+		k := r.round((x.Float() - o.Float()) / float64(step))
+		qv.SetFloat(o.Float() + k*float64(step))
+	default:
+		return v
+	}
+	return q
+}
+
+// roundTime returns t rounded in the direction r to the grid made of the instants origin + k * step,
+// in the location of the origin. The remainder is computed with big integers since the duration
+// between t and the origin may not fit in a time.Duration, e.g. with the zero time as origin.
+func roundTime(t, origin time.Time, step time.Duration, r rounding) time.Time {
+	d := big.NewInt(t.Unix() - origin.Unix())
+	d.Mul(d, big.NewInt(int64(time.Second)))
+	d.Add(d, big.NewInt(int64(t.Nanosecond()-origin.Nanosecond())))
+
+	rem := time.Duration(r.remainder(d, int64(step)).Int64())
+	return t.Add(-rem).In(origin.Location())
+}
@@ -0,0 +1,215 @@
+package intervalset
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRange_Quantize(t *testing.T) {
+	var table = []struct {
+		r        Range[int]
+		step     int
+		mode     QuantizeMode
+		expected Range[int]
+	}{
+		{NewRange(10, 100), 64, QuantizeOutward, NewRange(0, 128)},
+		{NewRange(10, 100), 64, QuantizeInward, NewRange(64, 64)},
+		{NewRange(10, 200), 64, QuantizeInward, NewRange(64, 192)},
+		{NewRange(10, 100), 64, QuantizeNearest, NewRange(0, 128)},
+		{NewRange(40, 90), 64, QuantizeNearest, NewRange(64, 64)},
+		{NewRange(-70, -10), 64, QuantizeOutward, NewRange(-128, 0)},
+		{NewRange(64, 128), 64, QuantizeOutward, NewRange(64, 128)},
+		{NewRange(10, 100), 0, QuantizeOutward, NewRange(10, 100)},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			got := tc.r.Quantize(tc.step, tc.mode)
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRange_QuantizeLimits(t *testing.T) {
+	var table = []struct {
+		r        Range[int64]
+		step     int64
+		mode     QuantizeMode
+		expected Range[int64]
+	}{
+		// the minimum and maximum values stand for unbounded limits
+		{NewRange[int64](0, math.MaxInt64), 64, QuantizeOutward, NewRange[int64](0, math.MaxInt64)},
+		{NewRange[int64](math.MinInt64, 0), 64, QuantizeInward, NewRange[int64](math.MinInt64, 0)},
+		{NewRange[int64](0, 1<<62+7), 1 << 62, QuantizeOutward, NewRange[int64](0, math.MaxInt64)},
+		{NewRange[int64](0, 1<<62+7), 1 << 62, QuantizeNearest, NewRange[int64](0, 1<<62)},
+		{NewRange[int64](-1<<62-7, 0), 1 << 62, QuantizeOutward, NewRange[int64](math.MinInt64, 0)},
+		{NewRange[int64](-1<<62-7, 1<<62+7), 1 << 62, QuantizeInward, NewRange[int64](-1<<62, 1<<62)},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			got := tc.r.Quantize(tc.step, tc.mode)
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRange_QuantizeLimitsUint(t *testing.T) {
+	var table = []struct {
+		r        Range[uint8]
+		mode     QuantizeMode
+		expected Range[uint8]
+	}{
+		{NewRange[uint8](0, math.MaxUint8), QuantizeOutward, NewRange[uint8](0, math.MaxUint8)},
+		{NewRange[uint8](10, 250), QuantizeOutward, NewRange[uint8](0, math.MaxUint8)},
+		{NewRange[uint8](10, 250), QuantizeNearest, NewRange[uint8](0, math.MaxUint8)},
+		{NewRange[uint8](10, 200), QuantizeInward, NewRange[uint8](64, 192)},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			got := tc.r.Quantize(64, tc.mode)
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRange_QuantizeFloat(t *testing.T) {
+	var table = []struct {
+		r        Range[float64]
+		mode     QuantizeMode
+		expected Range[float64]
+	}{
+		{NewRange(0.1, 0.9), QuantizeOutward, NewRange(0, 1.0)},
+		{NewRange(0.1, 0.9), QuantizeInward, NewRange(0.25, 0.75)},
+		{NewRange(0.1, 0.9), QuantizeNearest, NewRange(0, 1.0)},
+		{NewRange(0.3, 0.45), QuantizeInward, NewRange(0.5, 0.5)},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			got := tc.r.Quantize(0.25, tc.mode)
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPeriod_Quantize(t *testing.T) {
+	s := time.Date(2023, time.December, 1, 9, 0, 20, 0, time.UTC)
+	e := time.Date(2023, time.December, 1, 9, 4, 40, 0, time.UTC)
+	p := NewPeriod(s, e)
+
+	var table = []struct {
+		mode       QuantizeMode
+		start, end time.Time
+	}{
+		{QuantizeOutward, s.Truncate(time.Minute), e.Truncate(time.Minute).Add(time.Minute)},
+		{QuantizeInward, s.Truncate(time.Minute).Add(time.Minute), e.Truncate(time.Minute)},
+		{QuantizeNearest, s.Truncate(time.Minute), e.Truncate(time.Minute).Add(time.Minute)},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			got := p.Quantize(time.Minute, tc.mode, time.Time{})
+			if !got.Min().Equal(tc.start) || !got.Max().Equal(tc.end) {
+				t.Errorf("expected [%v, %v], got [%v, %v]", tc.start, tc.end, got.Min(), got.Max())
+			}
+		})
+	}
+}
+
+func TestPeriod_QuantizeOrigin(t *testing.T) {
+	loc := time.FixedZone("UTC+5:30", 5*3600+1800)
+	origin := time.Date(2023, time.December, 1, 0, 0, 0, 0, loc)
+
+	p := NewPeriod(
+		time.Date(2023, time.December, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2023, time.December, 2, 10, 0, 0, 0, time.UTC),
+	)
+
+	got := p.Quantize(24*time.Hour, QuantizeOutward, origin)
+	start, end := origin, origin.AddDate(0, 0, 2)
+	if !got.Min().Equal(start) || !got.Max().Equal(end) {
+		t.Errorf("expected [%v, %v], got [%v, %v]", start, end, got.Min(), got.Max())
+	}
+	if got.Min().Location() != loc {
+		t.Errorf("expected the dates to be in the location of the origin, got %v", got.Min().Location())
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	/*-----------------------------------------------------
+	|  T  | 0     64    128   192   256   320   384   448 |
+	| S   |   |-|   |--|        |-|      |--------|       |
+	-----------------------------------------------------*/
+	s := EmptySet[int]().Add(NewRange(10, 20), NewRange(70, 100), NewRange(200, 210), NewRange(300, 420))
+
+	var table = []struct {
+		mode     QuantizeMode
+		expected *IntervalSet[int]
+	}{
+		{QuantizeOutward, genExpectedRangeSet([]Interval[int]{NewRange(0, 128), NewRange(192, 448)})},
+		{QuantizeInward, genExpectedRangeSet([]Interval[int]{NewRange(320, 384)})},
+		{QuantizeNearest, genExpectedRangeSet([]Interval[int]{NewRange(64, 128), NewRange(320, 448)})},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			got := Quantize(s, 64, tc.mode, 0)
+			if !got.Equal(tc.expected) {
+				t.Errorf("both sets should be equal, expected %v, got %v", tc.expected, got.AsSlice())
+			}
+		})
+	}
+}
+
+func TestQuantize_Unbounded(t *testing.T) {
+	var r Range[int]
+	if err := r.Scan("[0,)"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	s := EmptySet[int]().Add(r, NewRange(-100, -10))
+
+	got := Quantize(s, 64, QuantizeOutward, 0)
+	expected := genExpectedRangeSet([]Interval[int]{NewRange(-128, 0), NewRange(0, math.MaxInt)})
+	if !got.Equal(EmptySet[int]().Add(expected.intervals...)) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected.AsSlice(), got.AsSlice())
+	}
+}
+
+func TestQuantize_Period(t *testing.T) {
+	s0 := time.Date(2023, time.December, 1, 9, 0, 0, 0, time.UTC)
+
+	s := EmptySet[time.Time]().Add(
+		NewPeriod(s0.Add(10*time.Second), s0.Add(50*time.Second)),
+		NewPeriod(s0.Add(70*time.Second), s0.Add(150*time.Second)),
+	)
+
+	got := Quantize(s, time.Minute, QuantizeOutward, s0)
+	expected := genExpectedPeriodSet([]Interval[time.Time]{NewPeriod(s0, s0.Add(3*time.Minute))})
+	if !got.Equal(expected) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, got.AsSlice())
+	}
+}
+
+func ExampleRange_Quantize() {
+	r := NewRange(10, 100)
+
+	fmt.Println(r.Quantize(64, QuantizeOutward))
+	fmt.Println(r.Quantize(64, QuantizeInward))
+	fmt.Println(r.Quantize(64, QuantizeNearest))
+	// Output:
+	// {0 128}
+	// {64 64}
+	// {0 128}
+}
@@ -1,6 +1,10 @@
 package intervalset
 
-import "golang.org/x/exp/constraints"
+import (
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
 
 // Number represents a value in a range.
 type Number interface {
@@ -12,7 +16,22 @@ func NewRange[T Number](l, u T) Range[T] {
 	return Range[T]{lower: l, upper: u}
 }
 
+// MakeRange returns a new range between lower and upper values,
+// or an error when a value is NaN or when lower is greater than upper.
+func MakeRange[T Number](l, u T) (Range[T], error) {
+	r := NewRange(l, u)
+	return r, r.validate()
+}
+
 // Range represents a range between two numbers.
+//
+// A range covers the values from its lower bound included to its upper bound excluded, [lower, upper).
+// Adjoining ranges such as [1, 5) and [5, 8) are therefore merged by sets, subtracting [3, 5) from
+// [1, 5) leaves [1, 3), the integer range [1, 5) covers 4 integers and ranges with equal bounds are empty.
+//
+// A range with a NaN bound is invalid: MakeRange rejects it and sets ignore it.
+// Infinite bounds represent unbounded ranges, e.g. [-Inf, 0] covers all the negative numbers,
+// and since -0 and +0 compare equal, ranges ending at -0 and starting at +0 touch.
 type Range[T Number] struct {
 	lower T
 	upper T
@@ -43,6 +62,28 @@ func (p Range[T]) IsEmpty() bool {
 	return p.lower == p.upper
 }
 
+func (p Range[T]) validate() error {
+	// NaN is the only value not equal to itself
+	if p.lower != p.lower || p.upper != p.upper {
+		return fmt.Errorf("%w: [%v, %v]", ErrNaN, p.lower, p.upper)
+	}
+	if p.lower > p.upper {
+		return fmt.Errorf("%w: [%v, %v]", ErrInvertedBounds, p.lower, p.upper)
+	}
+	return nil
+}
+
+// normalize returns the range with its bounds in order, NaN bounds cannot be normalized.
+func (p Range[T]) normalize() (Interval[T], bool) {
+	if p.lower != p.lower || p.upper != p.upper {
+		return p, false
+	}
+	if p.lower > p.upper {
+		return NewRange(p.upper, p.lower), true
+	}
+	return p, true
+}
+
 // Equal reports whether p is equal to q.
 // Two ranges are equal when their lower & upper values are equal.
 func (p Range[T]) Equal(q Interval[T]) bool {
@@ -69,6 +110,58 @@ func (p Range[T]) Contains(q Interval[T]) bool {
 	return q.Min() >= p.lower && q.Max() <= p.upper
 }
 
+// Meets reports whether p ends exactly when q starts.
+func (p Range[T]) Meets(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenMeets
+}
+
+// MetBy reports whether p starts exactly when q ends.
+func (p Range[T]) MetBy(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenMetBy
+}
+
+// StrictlyOverlaps reports whether p starts before q and ends during q.
+// Unlike Overlaps, it does not hold when p meets, contains or is contained by q.
+func (p Range[T]) StrictlyOverlaps(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenOverlaps
+}
+
+// OverlappedBy reports whether p starts during q and ends after q.
+func (p Range[T]) OverlappedBy(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenOverlappedBy
+}
+
+// Starts reports whether p and q start together and p ends before q.
+func (p Range[T]) Starts(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenStarts
+}
+
+// StartedBy reports whether p and q start together and p ends after q.
+func (p Range[T]) StartedBy(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenStartedBy
+}
+
+// During reports whether p starts after q and ends before q.
+func (p Range[T]) During(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenDuring
+}
+
+// StrictlyContains reports whether p starts before q and ends after q.
+// Unlike Contains, it does not hold when p and q share a limit.
+func (p Range[T]) StrictlyContains(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenContains
+}
+
+// Finishes reports whether p and q end together and p starts after q.
+func (p Range[T]) Finishes(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenFinishes
+}
+
+// FinishedBy reports whether p and q end together and p starts before q.
+func (p Range[T]) FinishedBy(q Interval[T]) bool {
+	return Relation[T](p, q) == AllenFinishedBy
+}
+
 // Intersect returns a new range representing the intersection of both ranges.
 // The new range is either a valid and non-empty range (its lower value being strictly
 // greater than its upper value) or a zero value range.
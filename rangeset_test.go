@@ -2,6 +2,8 @@ package intervalset
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
 	"testing"
 )
 
@@ -1138,3 +1140,459 @@ func TestRangeSet_IterBetween(t *testing.T) {
 		t.Errorf("expected both sets to be equal, s3 %+v, e3 %+v", s3, e3)
 	}
 }
+
+func TestAtLeast_range(t *testing.T) {
+	/*-------------------------------------------------------------
+	|  T  | 1   2   3   4   5   6   7   8   9   10   11   12   13 |
+	---------------------------------------------------------------
+	|  S  | |-----------|                       |---------------| |
+	|  S  |     |-----------|               |-------------|       |
+	|  S  | |-------|           |---|   |------------|            |
+	-------------------------------------------------------------*/
+	sets := []*IntervalSet[int]{
+		EmptySet[int]().Add(NewRange(1, 4), NewRange(10, 13)),
+		EmptySet[int]().Add(NewRange(2, 5), NewRange(9, 12)),
+		EmptySet[int]().Add(NewRange(1, 3), NewRange(6, 7), NewRange(8, 11)),
+	}
+
+	var table = []struct {
+		k int
+		e *IntervalSet[int]
+	}{
+		{0, Union(sets...)},
+		{1, Union(sets...)},
+		{2, genExpectedRangeSet([]Interval[int]{NewRange(1, 4), NewRange(9, 12)})},
+		{3, Intersection(sets...)},
+		{4, genExpectedRangeSet(nil)},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			got := AtLeast(tc.k, sets...)
+			if !got.Equal(tc.e) {
+				t.Errorf("both sets should be equal, expected %v, got %v", tc.e.AsSlice(), got.AsSlice())
+			}
+		})
+	}
+}
+
+func TestExactly_range(t *testing.T) {
+	sets := []*IntervalSet[int]{
+		EmptySet[int]().Add(NewRange(1, 4), NewRange(10, 13)),
+		EmptySet[int]().Add(NewRange(2, 5), NewRange(9, 12)),
+		EmptySet[int]().Add(NewRange(1, 3), NewRange(6, 7), NewRange(8, 11)),
+	}
+
+	var table = []struct {
+		k int
+		e *IntervalSet[int]
+	}{
+		{0, genExpectedRangeSet(nil)},
+		{1, genExpectedRangeSet([]Interval[int]{NewRange(4, 5), NewRange(6, 7), NewRange(8, 9), NewRange(12, 13)})},
+		{2, genExpectedRangeSet([]Interval[int]{NewRange(1, 2), NewRange(3, 4), NewRange(9, 10), NewRange(11, 12)})},
+		{3, genExpectedRangeSet([]Interval[int]{NewRange(2, 3), NewRange(10, 11)})},
+		{4, genExpectedRangeSet(nil)},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			got := Exactly(tc.k, sets...)
+			if !got.Equal(tc.e) {
+				t.Errorf("both sets should be equal, expected %v, got %v", tc.e.AsSlice(), got.AsSlice())
+			}
+		})
+	}
+}
+
+func genRandomRangeSets(r *rand.Rand, n, size int) []*IntervalSet[int] {
+	sets := make([]*IntervalSet[int], n)
+	for i := range sets {
+		sets[i] = EmptySet[int]()
+		for j := 0; j < size; j++ {
+			l := r.Intn(size * 10)
+			sets[i].Add(NewRange(l, l+1+r.Intn(20)))
+		}
+	}
+	return sets
+}
+
+func TestAtLeastAndExactly_randomSets(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 200; i++ {
+		sets := genRandomRangeSets(r, 1+r.Intn(5), 1+r.Intn(10))
+
+		// the coverage map counts the depth level by level
+		c := NewCoverageMap[int]()
+		for _, s := range sets {
+			c.Add(s.AsSlice()...)
+		}
+
+		for k := 1; k <= len(sets)+1; k++ {
+			if got, expected := AtLeast(k, sets...), c.AtLeast(k); !got.Equal(expected) {
+				t.Fatalf("AtLeast(%d) of %v: expected %v, got %v", k, sets, expected.AsSlice(), got.AsSlice())
+			}
+
+			expected := EmptySet[int]()
+			for _, seg := range c.Segments() {
+				if seg.Depth == k {
+					expected.Add(seg.Interval)
+				}
+			}
+			if got := Exactly(k, sets...); !got.Equal(expected) {
+				t.Fatalf("Exactly(%d) of %v: expected %v, got %v", k, sets, expected.AsSlice(), got.AsSlice())
+			}
+		}
+	}
+}
+
+func BenchmarkAtLeast(b *testing.B) {
+	sets := genRandomRangeSets(rand.New(rand.NewSource(1)), 5, 8000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		AtLeast(3, sets...)
+		Exactly(3, sets...)
+	}
+}
+
+func ExampleAtLeast_range() {
+	a := EmptySet[int]().Add(NewRange(1, 4))
+	b := EmptySet[int]().Add(NewRange(2, 6))
+	c := EmptySet[int]().Add(NewRange(3, 8))
+
+	// at least 2 out of a, b and c
+	s := AtLeast(2, a, b, c)
+
+	for _, p := range s.AsSlice() {
+		fmt.Printf("%d - %d\n", p.Min(), p.Max())
+	}
+
+	// Output:
+	// 2 - 6
+}
+
+func TestRangeSet_TryAdd(t *testing.T) {
+	/*----------------------------------------------
+	|  T  | 1   2   3   4   5   6   7   8   9   10 |
+	| S   |     |---|       |---|   |---|          |
+	----------------------------------------------*/
+	var table = []struct {
+		q         Interval[int]
+		conflicts []Interval[int]
+		ok        bool
+	}{
+		{NewRange(3, 5), []Interval[int]{}, true},
+		{NewRange(9, 10), []Interval[int]{}, true},
+		{NewRange(1, 6), []Interval[int]{NewRange(2, 3), NewRange(5, 6)}, false},
+		{NewRange(7, 9), []Interval[int]{NewRange(7, 8)}, false},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			s := EmptySet[int]().Add(NewRange(2, 3), NewRange(5, 6), NewRange(7, 8))
+			before := len(s.AsSlice())
+
+			conflicts, ok := s.TryAdd(tc.q)
+
+			if ok != tc.ok {
+				t.Errorf("expected %t, got %t", tc.ok, ok)
+			}
+			if !genExpectedRangeSet(conflicts).Equal(genExpectedRangeSet(tc.conflicts)) {
+				t.Errorf("expected conflicts %v, got %v", tc.conflicts, conflicts)
+			}
+			if !ok && len(s.AsSlice()) != before {
+				t.Errorf("expected the set to be left untouched, got %v", s.AsSlice())
+			}
+			if ok && s.Overlaps(tc.q).IsEmpty() {
+				t.Errorf("expected %v to be added, got %v", tc.q, s.AsSlice())
+			}
+		})
+	}
+}
+
+func TestRangeSet_AddDiffAndSubDiff(t *testing.T) {
+	/*----------------------------------------------
+	|  T  | 1   2   3   4   5   6   7   8   9   10 |
+	| S   |     |---|       |---|   |---|          |
+	----------------------------------------------*/
+	var table = []struct {
+		add      bool
+		q        []Interval[int]
+		diff     *IntervalSet[int]
+		expected *IntervalSet[int]
+	}{
+		{
+			true,
+			[]Interval[int]{NewRange(1, 6)},
+			genExpectedRangeSet([]Interval[int]{NewRange(1, 2), NewRange(3, 5)}),
+			genExpectedRangeSet([]Interval[int]{NewRange(1, 6), NewRange(7, 8)}),
+		},
+		{
+			true,
+			[]Interval[int]{NewRange(2, 3), NewRange(7, 8)},
+			EmptySet[int](),
+			genExpectedRangeSet([]Interval[int]{NewRange(2, 3), NewRange(5, 6), NewRange(7, 8)}),
+		},
+		{
+			true,
+			[]Interval[int]{NewRange(8, 10), NewRange(9, 11)},
+			genExpectedRangeSet([]Interval[int]{NewRange(8, 11)}),
+			genExpectedRangeSet([]Interval[int]{NewRange(2, 3), NewRange(5, 6), NewRange(7, 11)}),
+		},
+		{
+			false,
+			[]Interval[int]{NewRange(1, 6)},
+			genExpectedRangeSet([]Interval[int]{NewRange(2, 3), NewRange(5, 6)}),
+			genExpectedRangeSet([]Interval[int]{NewRange(7, 8)}),
+		},
+		{
+			false,
+			[]Interval[int]{NewRange(3, 5), NewRange(9, 10)},
+			EmptySet[int](),
+			genExpectedRangeSet([]Interval[int]{NewRange(2, 3), NewRange(5, 6), NewRange(7, 8)}),
+		},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			s := EmptySet[int]().Add(NewRange(2, 3), NewRange(5, 6), NewRange(7, 8))
+
+			var diff *IntervalSet[int]
+			if tc.add {
+				diff = s.AddDiff(tc.q...)
+			} else {
+				diff = s.SubDiff(tc.q...)
+			}
+
+			if !diff.Equal(tc.diff) {
+				t.Errorf("both sets should be equal, expected %v, got %v", tc.diff, diff.AsSlice())
+			}
+			if !s.Equal(tc.expected) {
+				t.Errorf("both sets should be equal, expected %v, got %v", tc.expected, s.AsSlice())
+			}
+		})
+	}
+}
+
+func TestRangeSet_OnChange(t *testing.T) {
+	var added, removed []Interval[int]
+	calls := 0
+
+	s := EmptySet[int]().OnChange(func(a, r *IntervalSet[int]) {
+		calls++
+		added = append(added, a.AsSlice()...)
+		removed = append(removed, r.AsSlice()...)
+	})
+
+	s.Add(NewRange(1, 4))
+	s.Add(NewRange(2, 3)) // already covered, no change
+	s.Sub(NewRange(3, 6))
+	s.Sub(NewRange(8, 9)) // not covered, no change
+	s.TryAdd(NewRange(4, 5))
+	s.TryAdd(NewRange(2, 5)) // conflicting, no change
+
+	if calls != 3 {
+		t.Errorf("expected the hook to be invoked 3 times, got %d", calls)
+	}
+
+	expected := []Interval[int]{NewRange(1, 4), NewRange(4, 5)}
+	if !genExpectedRangeSet(added).Equal(genExpectedRangeSet(expected)) {
+		t.Errorf("expected %v to be added, got %v", expected, added)
+	}
+
+	expected = []Interval[int]{NewRange(3, 4)}
+	if !genExpectedRangeSet(removed).Equal(genExpectedRangeSet(expected)) {
+		t.Errorf("expected %v to be removed, got %v", expected, removed)
+	}
+
+	s.OnChange(nil).Add(NewRange(8, 9))
+	if calls != 3 {
+		t.Errorf("expected the hook to be removed")
+	}
+}
+
+func ExampleIntervalSet_AddDiff() {
+	s := EmptySet[int]().Add(NewRange(2, 4), NewRange(6, 8))
+
+	fmt.Println(s.AddDiff(NewRange(1, 7)).AsSlice())
+	fmt.Println(s.SubDiff(NewRange(7, 10)).AsSlice())
+
+	// Output:
+	// [{1 2} {4 6}]
+	// [{7 8}]
+}
+
+// checkInvariant reports an error when the intervals of the set are not ordered,
+// are overlapping or touching, or when a bound is NaN.
+func checkInvariant(s *IntervalSet[float64]) error {
+	for i, v := range s.intervals {
+		if math.IsNaN(v.Min()) || math.IsNaN(v.Max()) {
+			return fmt.Errorf("interval %d has a NaN bound: %v", i, v)
+		}
+		if v.Min() >= v.Max() {
+			return fmt.Errorf("interval %d is inverted or empty: %v", i, v)
+		}
+		if i > 0 && s.intervals[i-1].Max() >= v.Min() {
+			return fmt.Errorf("intervals %d and %d are not ordered or disjoint: %v %v", i-1, i, s.intervals[i-1], v)
+		}
+	}
+	return nil
+}
+
+// coverageModel is a reference model of a float set whose bounds lie on a grid.
+// It records whether each segment between two consecutive points of the grid is covered.
+type coverageModel struct {
+	grid    []float64
+	covered []bool
+}
+
+func newCoverageModel(grid []float64) *coverageModel {
+	return &coverageModel{grid: grid, covered: make([]bool, len(grid)-1)}
+}
+
+// apply adds or subtracts q, which is ignored when it is invalid.
+func (m *coverageModel) apply(q Range[float64], add bool) {
+	if math.IsNaN(q.Min()) || math.IsNaN(q.Max()) || q.Min() > q.Max() {
+		return
+	}
+	for i := range m.covered {
+		if q.Min() <= m.grid[i] && m.grid[i+1] <= q.Max() {
+			m.covered[i] = add
+		}
+	}
+}
+
+// check returns an error when the coverage of s differs from the model.
+func (m *coverageModel) check(s *IntervalSet[float64]) error {
+	for i, c := range m.covered {
+		// a value strictly inside the segment
+		a, b := m.grid[i], m.grid[i+1]
+		x := a + (b-a)/2
+		switch {
+		case math.IsInf(a, -1):
+			x = b - 1
+		case math.IsInf(b, 1):
+			x = a + 1
+		}
+
+		got := false
+		for _, v := range s.intervals {
+			if v.Min() < x && x < v.Max() {
+				got = true
+			}
+		}
+		if got != c {
+			return fmt.Errorf("expected the coverage of %v to be %t, got %t", x, c, got)
+		}
+	}
+	return nil
+}
+
+func TestRangeSet_FloatInvariant(t *testing.T) {
+	r := rand.New(rand.NewSource(44))
+
+	// values include NaN, infinities and both zeros, the finite ones are multiples of 0.25 within [-20, 20]
+	values := []float64{math.NaN(), math.Inf(-1), math.Inf(1), math.Copysign(0, -1), 0, -2.5, -1, 1, 1.5, 3, 7.25, 10}
+	value := func() float64 {
+		if r.Intn(2) == 0 {
+			return values[r.Intn(len(values))]
+		}
+		return float64(r.Intn(40)-20) / 2
+	}
+
+	grid := []float64{math.Inf(-1)}
+	for v := -20.0; v <= 20; v += 0.25 {
+		grid = append(grid, v)
+	}
+	grid = append(grid, math.Inf(1))
+
+	for n := 0; n < 200; n++ {
+		s := EmptySet[float64]()
+		m := newCoverageModel(grid)
+
+		for i := 0; i < 30; i++ {
+			// empty ranges, including [-Inf, -Inf] and [+Inf, +Inf], are drawn too
+			q := NewRange(value(), value())
+
+			add := r.Intn(3) != 0
+			if add {
+				s.Add(q)
+			} else {
+				s.Sub(q)
+			}
+			m.apply(q, add)
+
+			if err := checkInvariant(s); err != nil {
+				t.Fatalf("invariant broken after %v: %v in %v", q, err, s.AsSlice())
+			}
+			if err := m.check(s); err != nil {
+				t.Fatalf("coverage lost after %v: %v in %v", q, err, s.AsSlice())
+			}
+		}
+
+		if l := Length(s); math.IsNaN(l) {
+			t.Fatalf("expected a length, got NaN for %v", s.AsSlice())
+		}
+	}
+}
+
+func TestRangeSet_FloatSpecialValues(t *testing.T) {
+	inf := math.Inf(1)
+	negZero := math.Copysign(0, -1)
+
+	var table = []struct {
+		add      []Interval[float64]
+		sub      []Interval[float64]
+		expected []Interval[float64]
+	}{
+		{
+			[]Interval[float64]{NewRange(math.NaN(), 1), NewRange(1, math.NaN())},
+			nil,
+			[]Interval[float64]{},
+		},
+		{
+			// -0 and +0 are equal, both ranges touch
+			[]Interval[float64]{NewRange(-1, negZero), NewRange(0.0, 1.0)},
+			nil,
+			[]Interval[float64]{NewRange(-1.0, 1.0)},
+		},
+		{
+			[]Interval[float64]{NewRange(-inf, 0), NewRange(5, inf)},
+			[]Interval[float64]{NewRange(-inf, -10), NewRange(math.NaN(), 6)},
+			[]Interval[float64]{NewRange(-10, 0.0), NewRange(5, inf)},
+		},
+		{
+			[]Interval[float64]{NewRange(-inf, inf)},
+			[]Interval[float64]{NewRange(0.0, 1.0)},
+			[]Interval[float64]{NewRange(-inf, 0), NewRange(1, inf)},
+		},
+		{
+			// empty ranges cover nothing and are dropped, even when added twice
+			[]Interval[float64]{NewRange(inf, inf), NewRange(inf, inf), NewRange(-inf, -inf), NewRange(2.0, 2.0), NewRange(2.0, 2.0)},
+			nil,
+			[]Interval[float64]{},
+		},
+		{
+			[]Interval[float64]{NewRange(0, inf), NewRange(inf, inf), NewRange(1.0, 1.0)},
+			[]Interval[float64]{NewRange(inf, inf), NewRange(3.0, 3.0)},
+			[]Interval[float64]{NewRange(0, inf)},
+		},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			s := EmptySet[float64]().Add(tc.add...).Sub(tc.sub...)
+			if !s.Equal(&IntervalSet[float64]{intervals: tc.expected}) {
+				t.Errorf("both sets should be equal, expected %v, got %v", tc.expected, s.AsSlice())
+			}
+		})
+	}
+
+	if l := Length(EmptySet[float64]().Add(NewRange(0, inf))); !math.IsInf(l, 1) {
+		t.Errorf("expected an infinite length, got %v", l)
+	}
+	if !NewRange(negZero, 1).Equal(NewRange(0.0, 1.0)) {
+		t.Errorf("expected ranges starting at -0 and +0 to be equal")
+	}
+}
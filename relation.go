@@ -0,0 +1,230 @@
+package intervalset
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// AllenRelation is one of the 13 relations of Allen's interval algebra.
+// Exactly one relation holds between two intervals:
+//
+//	| Relation          | Intervals     |
+//	|-------------------|---------------|
+//	| AllenBefore       | P ---         |
+//	|                   | Q       ---   |
+//	| AllenMeets        | P ---         |
+//	|                   | Q    ---      |
+//	| AllenOverlaps     | P -----       |
+//	|                   | Q    -----    |
+//	| AllenStarts       | P ---         |
+//	|                   | Q -------     |
+//	| AllenDuring       | P   ---       |
+//	|                   | Q -------     |
+//	| AllenFinishes     | P     ---     |
+//	|                   | Q -------     |
+//	| AllenEquals       | P -------     |
+//	|                   | Q -------     |
+//
+// The remaining relations AllenFinishedBy, AllenContains, AllenStartedBy,
+// AllenOverlappedBy, AllenMetBy and AllenAfter are their inverses.
+type AllenRelation int
+
+// Allen's interval algebra relations.
+const (
+	AllenBefore AllenRelation = iota
+	AllenMeets
+	AllenOverlaps
+	AllenStarts
+	AllenDuring
+	AllenFinishes
+	AllenEquals
+	AllenFinishedBy
+	AllenContains
+	AllenStartedBy
+	AllenOverlappedBy
+	AllenMetBy
+	AllenAfter
+)
+
+var allenRelationNames = [...]string{
+	"before",
+	"meets",
+	"overlaps",
+	"starts",
+	"during",
+	"finishes",
+	"equals",
+	"finished by",
+	"contains",
+	"started by",
+	"overlapped by",
+	"met by",
+	"after",
+}
+
+// String returns the name of the relation.
+func (r AllenRelation) String() string {
+	if r < AllenBefore || r > AllenAfter {
+		return fmt.Sprintf("AllenRelation(%d)", int(r))
+	}
+	return allenRelationNames[r]
+}
+
+// Inverse returns the inverse relation, that is the relation between q and p when r is the relation between p and q.
+func (r AllenRelation) Inverse() AllenRelation {
+	return AllenAfter - r
+}
+
+// Relation returns the relation of Allen's interval algebra holding between p and q.
+//
+// It only relies on the methods of the Interval interface, it can therefore be used with
+// any implementation. Allen's algebra being defined for non-empty intervals, an empty
+// interval sharing a limit with the other interval is reported as meeting it.
+func Relation[T any](p, q Interval[T]) AllenRelation {
+	if p.Before(q) {
+		return AllenBefore
+	}
+	if p.After(q) {
+		return AllenAfter
+	}
+
+	// p's limits compared to q's limits
+	mm := compareMin(p, q.Max())
+	if mm == 0 && !p.Equal(q) {
+		return AllenMetBy
+	}
+	xm := compareMax(p, q.Min())
+	if xm == 0 && !p.Equal(q) {
+		return AllenMeets
+	}
+
+	mn := compareMin(p, q.Min())
+	mx := compareMax(p, q.Max())
+
+	switch {
+	case mn == 0 && mx == 0:
+		return AllenEquals
+	case mn == 0 && mx < 0:
+		return AllenStarts
+	case mn == 0:
+		return AllenStartedBy
+	case mx == 0 && mn > 0:
+		return AllenFinishes
+	case mx == 0:
+		return AllenFinishedBy
+	case mn > 0 && mx < 0:
+		return AllenDuring
+	case mn < 0 && mx > 0:
+		return AllenContains
+	case mn < 0:
+		return AllenOverlaps
+	}
+	return AllenOverlappedBy
+}
+
+// compareMin compares the minimum value of p with v.
+// The result will be 0 if they are equal, -1 if p's minimum value is lower than v and +1 otherwise.
+func compareMin[T any](p Interval[T], v T) int {
+	if p.Equal(bounds[T]{min: v, max: p.Max()}) {
+		return 0
+	}
+	if p.After(bounds[T]{min: v, max: v}) {
+		return 1
+	}
+	return -1
+}
+
+// compareMax compares the maximum value of p with v.
+// The result will be 0 if they are equal, -1 if p's maximum value is lower than v and +1 otherwise.
+func compareMax[T any](p Interval[T], v T) int {
+	if p.Equal(bounds[T]{min: p.Min(), max: v}) {
+		return 0
+	}
+	if p.Before(bounds[T]{min: v, max: v}) {
+		return -1
+	}
+	return 1
+}
+
+// bounds is an interval solely defined by its limits.
+//
+// Since T might not be ordered, bounds cannot compare its limits and relies on the
+// comparisons made by the other interval instead. It is therefore meant to be compared
+// with intervals of other types, allowing us to compare the limits of any implementation.
+type bounds[T any] struct {
+	min T
+	max T
+}
+
+// Min returns the minimum value of the interval.
+func (b bounds[T]) Min() T {
+	return b.min
+}
+
+// Max returns the maximum value of the interval.
+func (b bounds[T]) Max() T {
+	return b.max
+}
+
+// IsZero reports whether both limits are zero values.
+func (b bounds[T]) IsZero() bool {
+	return reflect.ValueOf(&b.min).Elem().IsZero() && reflect.ValueOf(&b.max).Elem().IsZero()
+}
+
+// Equal reports whether b is equal to q.
+func (b bounds[T]) Equal(q Interval[T]) bool {
+	return q.Equal(b)
+}
+
+// Before reports whether b ends before the beginning of q.
+func (b bounds[T]) Before(q Interval[T]) bool {
+	return q.After(bounds[T]{min: b.max, max: b.max})
+}
+
+// After reports whether b starts after the end of q.
+func (b bounds[T]) After(q Interval[T]) bool {
+	return q.Before(bounds[T]{min: b.min, max: b.min})
+}
+
+// Overlaps reports whether b overlaps q.
+func (b bounds[T]) Overlaps(q Interval[T]) bool {
+	return !b.Before(q) && !b.After(q)
+}
+
+// Contains reports whether b contains q.
+func (b bounds[T]) Contains(q Interval[T]) bool {
+	return compareMin(q, b.min) >= 0 && compareMax(q, b.max) <= 0
+}
+
+// Intersect returns a new interval representing the intersection of both intervals.
+func (b bounds[T]) Intersect(q Interval[T]) Interval[T] {
+	return q.Intersect(b)
+}
+
+// Encompass returns a new interval encompassing both intervals.
+func (b bounds[T]) Encompass(q Interval[T]) Interval[T] {
+	return q.Encompass(b)
+}
+
+// Punch cuts q out of b and returns the remaining intervals.
+func (b bounds[T]) Punch(q Interval[T]) (Interval[T], Interval[T]) {
+	if b.Before(q) {
+		return b, bounds[T]{}
+	}
+	if b.After(q) {
+		return bounds[T]{}, b
+	}
+
+	l := bounds[T]{}
+	r := bounds[T]{}
+
+	if compareMin(q, b.min) > 0 {
+		l = bounds[T]{min: b.min, max: q.Min()}
+	}
+
+	if compareMax(q, b.max) < 0 {
+		r = bounds[T]{min: q.Max(), max: b.max}
+	}
+
+	return l, r
+}
@@ -0,0 +1,202 @@
+package intervalset
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRelation_range(t *testing.T) {
+	var table = []struct {
+		e  AllenRelation
+		i1 Range[int]
+		i2 Range[int]
+	}{
+		/*----------------------------------------------
+		|  T  | 1   2   3   4   5   6   7   8   9   10 |
+		| i1  |     |---|                              |
+		| i2  |             |-------|                  |
+		----------------------------------------------*/
+		{AllenBefore, NewRange(2, 3), NewRange(4, 6)},
+		/*----------------------------------------------
+		|  T  | 1   2   3   4   5   6   7   8   9   10 |
+		| i1  |     |-------|                          |
+		| i2  |             |-------|                  |
+		----------------------------------------------*/
+		{AllenMeets, NewRange(2, 4), NewRange(4, 6)},
+		/*----------------------------------------------
+		|  T  | 1   2   3   4   5   6   7   8   9   10 |
+		| i1  |     |-----------|                      |
+		| i2  |             |-------|                  |
+		----------------------------------------------*/
+		{AllenOverlaps, NewRange(2, 5), NewRange(4, 6)},
+		/*----------------------------------------------
+		|  T  | 1   2   3   4   5   6   7   8   9   10 |
+		| i1  |             |---|                      |
+		| i2  |             |-------|                  |
+		----------------------------------------------*/
+		{AllenStarts, NewRange(4, 5), NewRange(4, 6)},
+		/*----------------------------------------------
+		|  T  | 1   2   3   4   5   6   7   8   9   10 |
+		| i1  |                 |---|                  |
+		| i2  |             |-----------|              |
+		----------------------------------------------*/
+		{AllenDuring, NewRange(5, 6), NewRange(4, 7)},
+		/*----------------------------------------------
+		|  T  | 1   2   3   4   5   6   7   8   9   10 |
+		| i1  |                 |---|                  |
+		| i2  |             |-------|                  |
+		----------------------------------------------*/
+		{AllenFinishes, NewRange(5, 6), NewRange(4, 6)},
+		/*----------------------------------------------
+		|  T  | 1   2   3   4   5   6   7   8   9   10 |
+		| i1  |             |-------|                  |
+		| i2  |             |-------|                  |
+		----------------------------------------------*/
+		{AllenEquals, NewRange(4, 6), NewRange(4, 6)},
+		/*----------------------------------------------
+		|  T  | 1   2   3   4   5   6   7   8   9   10 |
+		| i1  |             |-------|                  |
+		| i2  |                 |---|                  |
+		----------------------------------------------*/
+		{AllenFinishedBy, NewRange(4, 6), NewRange(5, 6)},
+		/*----------------------------------------------
+		|  T  | 1   2   3   4   5   6   7   8   9   10 |
+		| i1  |             |-----------|              |
+		| i2  |                 |---|                  |
+		----------------------------------------------*/
+		{AllenContains, NewRange(4, 7), NewRange(5, 6)},
+		/*----------------------------------------------
+		|  T  | 1   2   3   4   5   6   7   8   9   10 |
+		| i1  |             |-------|                  |
+		| i2  |             |---|                      |
+		----------------------------------------------*/
+		{AllenStartedBy, NewRange(4, 6), NewRange(4, 5)},
+		/*----------------------------------------------
+		|  T  | 1   2   3   4   5   6   7   8   9   10 |
+		| i1  |             |-------|                  |
+		| i2  |     |-----------|                      |
+		----------------------------------------------*/
+		{AllenOverlappedBy, NewRange(4, 6), NewRange(2, 5)},
+		/*----------------------------------------------
+		|  T  | 1   2   3   4   5   6   7   8   9   10 |
+		| i1  |             |-------|                  |
+		| i2  |     |-------|                          |
+		----------------------------------------------*/
+		{AllenMetBy, NewRange(4, 6), NewRange(2, 4)},
+		/*----------------------------------------------
+		|  T  | 1   2   3   4   5   6   7   8   9   10 |
+		| i1  |             |-------|                  |
+		| i2  |     |---|                              |
+		----------------------------------------------*/
+		{AllenAfter, NewRange(4, 6), NewRange(2, 3)},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			if r := Relation[int](tc.i1, tc.i2); r != tc.e {
+				t.Errorf("expected i1 %s i2, got %s: i1 %+v, i2 %+v", tc.e, r, tc.i1, tc.i2)
+			}
+			if r := Relation[int](tc.i2, tc.i1); r != tc.e.Inverse() {
+				t.Errorf("expected i2 %s i1, got %s: i1 %+v, i2 %+v", tc.e.Inverse(), r, tc.i1, tc.i2)
+			}
+		})
+	}
+}
+
+func TestRelation_period(t *testing.T) {
+	d := func(day int) time.Time {
+		return time.Date(2023, time.December, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	var table = []struct {
+		e  AllenRelation
+		i1 Period[time.Time]
+		i2 Period[time.Time]
+	}{
+		{AllenBefore, NewPeriod(d(2), d(3)), NewPeriod(d(4), d(6))},
+		{AllenMeets, NewPeriod(d(2), d(4)), NewPeriod(d(4), d(6))},
+		{AllenOverlaps, NewPeriod(d(2), d(5)), NewPeriod(d(4), d(6))},
+		{AllenStarts, NewPeriod(d(4), d(5)), NewPeriod(d(4), d(6))},
+		{AllenDuring, NewPeriod(d(5), d(6)), NewPeriod(d(4), d(7))},
+		{AllenFinishes, NewPeriod(d(5), d(6)), NewPeriod(d(4), d(6))},
+		{AllenEquals, NewPeriod(d(4), d(6)), NewPeriod(d(4), d(6).In(time.FixedZone("", 3600)))},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			if r := Relation[time.Time](tc.i1, tc.i2); r != tc.e {
+				t.Errorf("expected i1 %s i2, got %s: i1 %+v, i2 %+v", tc.e, r, tc.i1, tc.i2)
+			}
+			if r := Relation[time.Time](tc.i2, tc.i1); r != tc.e.Inverse() {
+				t.Errorf("expected i2 %s i1, got %s: i1 %+v, i2 %+v", tc.e.Inverse(), r, tc.i1, tc.i2)
+			}
+		})
+	}
+}
+
+func TestRelation_exclusive(t *testing.T) {
+	// exactly one relation holds between any pair of non-empty intervals
+	ranges := make([]Range[int], 0)
+	for l := 1; l <= 5; l++ {
+		for u := l + 1; u <= 6; u++ {
+			ranges = append(ranges, NewRange(l, u))
+		}
+	}
+
+	for _, p := range ranges {
+		for _, q := range ranges {
+			predicates := []struct {
+				r  AllenRelation
+				ok bool
+			}{
+				{AllenBefore, p.Before(q)},
+				{AllenMeets, p.Meets(q)},
+				{AllenOverlaps, p.StrictlyOverlaps(q)},
+				{AllenStarts, p.Starts(q)},
+				{AllenDuring, p.During(q)},
+				{AllenFinishes, p.Finishes(q)},
+				{AllenEquals, p.Equal(q)},
+				{AllenFinishedBy, p.FinishedBy(q)},
+				{AllenContains, p.StrictlyContains(q)},
+				{AllenStartedBy, p.StartedBy(q)},
+				{AllenOverlappedBy, p.OverlappedBy(q)},
+				{AllenMetBy, p.MetBy(q)},
+				{AllenAfter, p.After(q)},
+			}
+
+			c := 0
+			for _, v := range predicates {
+				if v.ok {
+					c++
+					if r := Relation[int](p, q); r != v.r {
+						t.Errorf("expected relation %s, got %s: p %+v, q %+v", v.r, r, p, q)
+					}
+				}
+			}
+			if c != 1 {
+				t.Errorf("expected exactly one relation to hold, got %d: p %+v, q %+v", c, p, q)
+			}
+		}
+	}
+}
+
+func TestAllenRelation_String(t *testing.T) {
+	if s := AllenOverlappedBy.String(); s != "overlapped by" {
+		t.Errorf("unexpected name %q", s)
+	}
+	if s := AllenRelation(42).String(); s != "AllenRelation(42)" {
+		t.Errorf("unexpected name %q", s)
+	}
+}
+
+func ExampleRelation() {
+	fmt.Println(Relation[int](NewRange(1, 3), NewRange(3, 5)))
+	fmt.Println(Relation[int](NewRange(2, 4), NewRange(1, 5)))
+	fmt.Println(Relation[int](NewRange(1, 5), NewRange(3, 5)))
+
+	// Output:
+	// meets
+	// during
+	// finished by
+}
@@ -0,0 +1,219 @@
+package intervalset
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Track is a labelled set drawn by Render.
+type Track[T any] struct {
+	Label string
+	Set   *IntervalSet[T]
+}
+
+// RenderOptions defines how Render draws the tracks.
+type RenderOptions[T any] struct {
+	// Width is the number of columns of the axis, defaults to 60.
+	Width int
+
+	// Ticks is the number of tick marks on the axis, defaults to 5.
+	Ticks int
+
+	// Window is the portion of the axis to draw, defaults to the portion covered by the tracks.
+	Window Interval[T]
+
+	// Format formats the values of the tick marks.
+	// Times are formatted as "2006-01-02 15:04" and numbers with fmt.Sprint by default.
+	Format func(T) string
+
+	// Unicode draws the bars with box-drawing characters instead of ASCII ones.
+	Unicode bool
+}
+
+type glyphs struct {
+	start, fill, end, single, axis, tick rune
+}
+
+var (
+	asciiGlyphs   = glyphs{'|', '-', '|', '|', '-', '+'}
+	unicodeGlyphs = glyphs{'├', '─', '┤', '│', '─', '┼'}
+)
+
+// Render draws the tracks as bars over a common axis, one line per track, below the tick marks:
+//
+//	  2       4       6       8      10
+//	  +-------+-------+-------+-------+
+//	a |---------------|
+//	b         |---------------|   |---|
+//
+// Values are mapped to the closest column, so intervals shorter than a column may look
+// like they touch. It is meant for debugging, for instance in test failure messages.
+func Render[T any](opts RenderOptions[T], tracks ...Track[T]) string {
+	width := opts.Width
+	if width <= 0 {
+		width = 60
+	}
+	ticks := opts.Ticks
+	if ticks <= 0 {
+		ticks = 5
+	}
+	format := opts.Format
+	if format == nil {
+		format = formatTick[T]
+	}
+	g := asciiGlyphs
+	if opts.Unicode {
+		g = unicodeGlyphs
+	}
+
+	window := opts.Window
+	if window == nil || window.IsZero() {
+		window = extentOf(tracks)
+	}
+
+	labelWidth := 0
+	for _, t := range tracks {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(t.Label))
+	}
+	indent := strings.Repeat(" ", labelWidth+1)
+
+	var b strings.Builder
+	if window == nil {
+		for _, t := range tracks {
+			b.WriteString(strings.TrimRight(pad(t.Label, labelWidth), " ") + "\n")
+		}
+		return b.String()
+	}
+
+	lo, hi := position(window.Min()), position(window.Max())
+	column := func(v T) int {
+		if hi == lo {
+			return 0
+		}
+		c := int(math.Round((position(v) - lo) / (hi - lo) * float64(width-1)))
+		return min(max(c, 0), width-1)
+	}
+
+	// tick marks
+	labels := []rune(strings.Repeat(" ", width))
+	axis := []rune(strings.Repeat(string(g.axis), width))
+	next := 0
+	for i := 0; i < ticks; i++ {
+		f := lo
+		if ticks > 1 {
+			f += (hi - lo) * float64(i) / float64(ticks-1)
+		}
+		v := valueAt(window.Min(), f)
+		c := column(v)
+		axis[c] = g.tick
+
+		// labels are shifted to fit within the axis and skipped when overlapping the previous one
+		l := []rune(format(v))
+		s := min(c, width-len(l))
+		if s < next || s < 0 {
+			continue
+		}
+		copy(labels[s:], l)
+		next = s + len(l) + 1
+	}
+	b.WriteString(strings.TrimRight(indent+string(labels), " ") + "\n")
+	b.WriteString(indent + string(axis) + "\n")
+
+	// bars
+	for _, t := range tracks {
+		line := []rune(strings.Repeat(" ", width))
+		if t.Set != nil {
+			for _, q := range t.Set.intervals {
+				if q = q.Intersect(window); q.IsZero() {
+					continue
+				}
+				l, u := column(q.Min()), column(q.Max())
+				if l == u {
+					line[l] = g.single
+					continue
+				}
+				for c := l + 1; c < u; c++ {
+					line[c] = g.fill
+				}
+				line[l], line[u] = g.start, g.end
+			}
+		}
+		b.WriteString(strings.TrimRight(pad(t.Label, labelWidth)+" "+string(line), " ") + "\n")
+	}
+
+	return b.String()
+}
+
+// extentOf returns the interval from the minimum to the maximum of the tracks, nil when they are empty.
+func extentOf[T any](tracks []Track[T]) Interval[T] {
+	var lo, hi T
+	found := false
+	for _, t := range tracks {
+		if t.Set == nil || t.Set.IsEmpty() {
+			continue
+		}
+		l, u := t.Set.intervals[0].Min(), t.Set.intervals[len(t.Set.intervals)-1].Max()
+		if !found || position(l) < position(lo) {
+			lo = l
+		}
+		if !found || position(u) > position(hi) {
+			hi = u
+		}
+		found = true
+	}
+	if !found {
+		return nil
+	}
+	return bounds[T]{min: lo, max: hi}
+}
+
+func pad(s string, n int) string {
+	return s + strings.Repeat(" ", n-utf8.RuneCountInString(s))
+}
+
+// position returns the position of a value on the axis.
+func position[T any](v T) float64 {
+	if t, ok := any(v).(time.Time); ok {
+		return float64(t.UnixNano())
+	}
+	r := reflect.ValueOf(v)
+	switch r.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(r.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(r.Uint())
+	case reflect.Float32, reflect.Float64:
+		return r.Float()
+	}
+	return 0
+}
+
+// valueAt returns the value at the given position on the axis, of the same kind as ref.
+// Integers are rounded to the closest value and times share the location of ref.
+func valueAt[T any](ref T, f float64) T {
+	if t, ok := any(ref).(time.Time); ok {
+		return any(time.Unix(0, int64(f)).In(t.Location())).(T)
+	}
+	var v T
+	r := reflect.ValueOf(&v).Elem()
+	switch r.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		r.SetInt(int64(math.Round(f)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		r.SetUint(uint64(math.Round(f)))
+	case reflect.Float32, reflect.Float64:
+		r.SetFloat(f)
+	}
+	return v
+}
+
+func formatTick[T any](v T) string {
+	if t, ok := any(v).(time.Time); ok {
+		return t.Format("2006-01-02 15:04")
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,97 @@
+package intervalset
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	a := EmptySet[int]().Add(NewRange(2, 6))
+	b := EmptySet[int]().Add(NewRange(4, 8), NewRange(9, 10))
+
+	var table = []struct {
+		opts     RenderOptions[int]
+		tracks   []Track[int]
+		expected string
+	}{
+		{
+			RenderOptions[int]{Width: 33},
+			[]Track[int]{{"a", a}, {"b", b}},
+			"  2       4       6       8      10\n" +
+				"  +-------+-------+-------+-------+\n" +
+				"a |---------------|\n" +
+				"b         |---------------|   |---|\n",
+		},
+		{
+			RenderOptions[int]{Width: 13, Ticks: 3, Window: NewRange(0, 12), Unicode: true},
+			[]Track[int]{{"a", a}, {"bb", b}},
+			"   0     6    12\n" +
+				"   ┼─────┼─────┼\n" +
+				"a    ├───┤\n" +
+				"bb     ├───┤├┤\n",
+		},
+		{
+			// intervals are clipped to the window
+			RenderOptions[int]{Width: 5, Ticks: 2, Window: NewRange(5, 9)},
+			[]Track[int]{{"a", a}, {"b", b}},
+			"  5   9\n" +
+				"  +---+\n" +
+				"a ||\n" +
+				"b |--|\n",
+		},
+		{
+			RenderOptions[int]{},
+			[]Track[int]{{"empty", EmptySet[int]()}, {"nil", nil}},
+			"empty\n" +
+				"nil\n",
+		},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			got := Render(tc.opts, tc.tracks...)
+			if got != tc.expected {
+				t.Errorf("unexpected rendering, expected\n%s\ngot\n%s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRender_Period(t *testing.T) {
+	s := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	p := EmptySet[time.Time]().Add(NewPeriod(s, s.Add(time.Hour)), NewPeriod(s.Add(3*time.Hour), s.Add(4*time.Hour)))
+
+	got := Render(RenderOptions[time.Time]{
+		Width: 25,
+		Format: func(t time.Time) string {
+			return t.Format("15:04")
+		},
+	}, Track[time.Time]{"p", p})
+
+	expected := "  09:00 10:00 11:00 12:00\n" +
+		"  +-----+-----+-----+-----+\n" +
+		"p |-----|           |-----|\n"
+
+	if got != expected {
+		t.Errorf("unexpected rendering, expected\n%s\ngot\n%s", expected, got)
+	}
+}
+
+func ExampleRender() {
+	a := EmptySet[int]().Add(NewRange(0, 4), NewRange(6, 10))
+	b := EmptySet[int]().Add(NewRange(3, 7))
+
+	fmt.Print(Render(RenderOptions[int]{Width: 21, Ticks: 3},
+		Track[int]{"a", a},
+		Track[int]{"b", b},
+		Track[int]{"a∩b", Intersection(a, b)},
+	))
+
+	// Output:
+	//     0         5        10
+	//     +---------+---------+
+	// a   |-------|   |-------|
+	// b         |-------|
+	// a∩b       |-|   |-|
+}
@@ -0,0 +1,123 @@
+package intervalset
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"time"
+)
+
+// SamplePoint returns a value drawn uniformly at random from the values covered by the set,
+// each interval being picked with a probability proportional to its length. As with Count,
+// the ranges of integer sets are seen as sets of integers, [l, u) holding the integers from l
+// included to u excluded. It reports false when the set is empty or unbounded.
+func SamplePoint[T any](s *IntervalSet[T], r *rand.Rand) (T, bool) {
+	length := 0.0
+	if k := kindOf[T](); k == kindInt || k == kindUint {
+		length = 1
+	}
+
+	return sampleStart(s, length, r)
+}
+
+// SampleInterval returns an interval of the given length drawn uniformly at random among the
+// intervals fitting entirely inside the set, length being a number for ranges or a time.Duration
+// for periods. It reports false when no interval of the set is long enough or when the set is unbounded.
+func SampleInterval[T any, G Number](s *IntervalSet[T], length G, r *rand.Rand) (Interval[T], bool) {
+	if length < 0 {
+		return nil, false
+	}
+
+	l, ok := sampleStart(s, float64(length), r)
+	if !ok {
+		return nil, false
+	}
+	return newInterval(l, advance(l, float64(length)))
+}
+
+// sampleStart returns the start of a block of the given length drawn uniformly at random
+// among the blocks fitting inside the intervals of the set.
+func sampleStart[T any](s *IntervalSet[T], length float64, r *rand.Rand) (T, bool) {
+	var zero T
+
+	k := kindOf[T]()
+	discrete := k == kindInt || k == kindUint
+
+	// weights are the measures of the possible starts in each interval,
+	// the number of possible starts for integers
+	weights := make([]float64, len(s.intervals))
+	total, eligible := 0.0, 0
+	for i, v := range s.intervals {
+		d := distance(v.Min(), v.Max())
+		if math.IsInf(d, 0) || math.IsNaN(d) {
+			return zero, false
+		}
+		if d < length || (discrete && d == 0) {
+			weights[i] = -1
+			continue
+		}
+		weights[i] = d - length
+		if discrete {
+			weights[i]++
+		}
+		total += weights[i]
+		eligible++
+	}
+	if eligible == 0 {
+		return zero, false
+	}
+
+	// the blocks fit exactly, they are all equally likely
+	if total == 0 {
+		n := r.Intn(eligible)
+		for i, w := range weights {
+			if w < 0 {
+				continue
+			}
+			if n == 0 {
+				return s.intervals[i].Min(), true
+			}
+			n--
+		}
+	}
+
+	x := r.Float64() * total
+	if discrete {
+		x = math.Floor(x)
+	}
+	last := 0
+	for i, w := range weights {
+		if w < 0 {
+			continue
+		}
+		last = i
+		if x < w {
+			return advance(s.intervals[i].Min(), x), true
+		}
+		x -= w
+	}
+
+	// rounding errors may leave x slightly past the total, use the last possible start
+	if discrete {
+		return advance(s.intervals[last].Min(), weights[last]-1), true
+	}
+	return advance(s.intervals[last].Min(), weights[last]), true
+}
+
+// advance returns v moved forward by d, a number of nanoseconds for times.
+func advance[T any](v T, d float64) T {
+	if t, ok := any(v).(time.Time); ok {
+		return any(t.Add(time.Duration(d))).(T)
+	}
+
+	x := reflect.ValueOf(&v).Elem()
+	switch x.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x.SetInt(x.Int() + int64(d))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x.SetUint(x.Uint() + uint64(d))
+	case reflect.Float32, reflect.Float64:
+		x.SetFloat(x.Float() + d)
+	}
+	return v
+}
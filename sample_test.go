@@ -0,0 +1,160 @@
+package intervalset
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSamplePoint(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	// the second range is three times longer than the first one
+	s := EmptySet[float64]().Add(NewRange(0, 1.0), NewRange(10, 13.0))
+
+	n, first := 10000, 0
+	for i := 0; i < n; i++ {
+		v, ok := SamplePoint(s, r)
+		if !ok || !(v >= 0 && v <= 1 || v >= 10 && v <= 13) {
+			t.Fatalf("expected a point of the set, got %v, %t", v, ok)
+		}
+		if v <= 1 {
+			first++
+		}
+	}
+
+	if f := float64(first) / float64(n); math.Abs(f-0.25) > 0.02 {
+		t.Errorf("expected about a quarter of the points in the first range, got %v", f)
+	}
+}
+
+func TestSamplePoint_Integers(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := EmptySet[int]().Add(NewRange(1, 3), NewRange(5, 6), NewRange(8, 8))
+
+	counts := make(map[int]int)
+	for i := 0; i < 3000; i++ {
+		v, ok := SamplePoint(s, r)
+		if !ok {
+			t.Fatal("expected a point")
+		}
+		counts[v]++
+	}
+
+	if len(counts) != 3 {
+		t.Errorf("expected the points 1, 2 and 5, got %v", counts)
+	}
+	for _, v := range []int{1, 2, 5} {
+		if c := counts[v]; c < 900 || c > 1100 {
+			t.Errorf("expected about 1000 draws of %d, got %d", v, c)
+		}
+	}
+}
+
+func TestSamplePoint_Unsampleable(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	var table = []*IntervalSet[float64]{
+		EmptySet[float64](),
+		EmptySet[float64]().Add(NewRange(0, math.Inf(1))),
+		EmptySet[float64]().Add(NewRange(0, 1.0), NewRange(math.Inf(-1), -1)),
+	}
+
+	for i, s := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			if v, ok := SamplePoint(s, r); ok {
+				t.Errorf("expected no point, got %v", v)
+			}
+		})
+	}
+}
+
+func TestSampleInterval(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	/*-------------------------------------------------------
+	|  T  | 0   2   4   6   8   10  12  14  16  18  20      |
+	| S   | |---|   |-----------|       |-----------|       |
+	-------------------------------------------------------*/
+	s := EmptySet[int]().Add(NewRange(0, 2), NewRange(4, 10), NewRange(14, 20))
+
+	var table = []struct {
+		length int
+		starts int
+		ok     bool
+	}{
+		{1, 14, true},
+		{2, 11, true},
+		{6, 2, true},
+		{7, 0, false},
+		{-1, 0, false},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			starts := make(map[int]bool)
+			for j := 0; j < 1000; j++ {
+				q, ok := SampleInterval(s, tc.length, r)
+				if ok != tc.ok {
+					t.Fatalf("expected %t, got %t", tc.ok, ok)
+				}
+				if !ok {
+					return
+				}
+				if q.Max()-q.Min() != tc.length {
+					t.Fatalf("expected an interval of length %d, got %v", tc.length, q)
+				}
+				if !s.IsSubset(EmptySet[int]().Add(q)) {
+					t.Fatalf("expected an interval inside the set, got %v", q)
+				}
+				starts[q.Min()] = true
+			}
+			if len(starts) != tc.starts {
+				t.Errorf("expected %d possible starts, got %v", tc.starts, starts)
+			}
+		})
+	}
+}
+
+func TestSampleInterval_Period(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s0 := time.Date(2023, time.December, 1, 9, 0, 0, 0, time.UTC)
+
+	s := EmptySet[time.Time]().Add(
+		NewPeriod(s0, s0.Add(30*time.Minute)),
+		NewPeriod(s0.Add(time.Hour), s0.Add(3*time.Hour)),
+		NewPeriod(s0.Add(4*time.Hour), s0.Add(5*time.Hour)),
+	)
+
+	for i := 0; i < 1000; i++ {
+		q, ok := SampleInterval(s, time.Hour, r)
+		if !ok {
+			t.Fatal("expected an interval")
+		}
+		if q.Max().Sub(q.Min()) != time.Hour || !s.IsSubset(EmptySet[time.Time]().Add(q)) {
+			t.Fatalf("expected an hour inside the set, got %v", q)
+		}
+		if q.Min().Before(s0.Add(time.Hour)) {
+			t.Fatalf("expected the first period to be too short, got %v", q)
+		}
+	}
+
+	// the last period fits exactly an hour
+	s.Sub(NewPeriod(s0.Add(time.Hour), s0.Add(3*time.Hour)))
+	q, ok := SampleInterval(s, time.Hour, r)
+	if !ok || !q.Min().Equal(s0.Add(4*time.Hour)) {
+		t.Errorf("expected the last period, got %v, %t", q, ok)
+	}
+}
+
+func ExampleSampleInterval() {
+	r := rand.New(rand.NewSource(42))
+	s := EmptySet[int]().Add(NewRange(0, 10), NewRange(20, 22))
+
+	q, ok := SampleInterval(s, 10, r)
+	fmt.Println(q, ok)
+	// Output:
+	// {0 10} true
+}
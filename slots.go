@@ -0,0 +1,120 @@
+package intervalset
+
+import (
+	"sort"
+	"time"
+
+	limit "github.com/mickaelvieira/intervalset/internal/time"
+)
+
+// SlotRanking defines the order of the slots returned by FindSlots.
+type SlotRanking int
+
+const (
+	// RankEarliest ranks the earliest slots first.
+	RankEarliest SlotRanking = iota
+
+	// RankRoomiest ranks first the slots surrounded by the most free time,
+	// the earliest slots coming first among equally free slots.
+	RankRoomiest
+)
+
+// SlotOptions configures the search of slots.
+type SlotOptions struct {
+	// Busy reports whether the sets describe busy time rather than free time.
+	Busy bool
+
+	// Step aligns the start of the slots on multiples of the step from midnight
+	// in the location of the window, e.g. every 15 minutes. When the step is zero,
+	// a single slot is proposed at the beginning of each free period.
+	Step time.Duration
+
+	// Before and After are the buffers of free time required before and after a slot.
+	// Buffers may extend beyond the search window.
+	Before time.Duration
+	After  time.Duration
+
+	// Rank defines the order of the slots.
+	Rank SlotRanking
+
+	// Limit is the maximum number of slots returned, zero meaning no limit.
+	Limit int
+}
+
+// FindSlots returns the candidate periods of duration d within the window during which
+// all participants are available, ranked according to the options.
+//
+// Each set describes the availability of a participant, either their free time or, when
+// opts.Busy is set, their busy time. The participants' common free time is obtained by
+// intersecting the sets of free time or by complementing the union of the sets of busy time.
+func FindSlots(sets []*IntervalSet[time.Time], d time.Duration, window Period[time.Time], opts SlotOptions) []Period[time.Time] {
+	slots := make([]Period[time.Time], 0)
+
+	if d <= 0 || len(sets) == 0 || !window.IsValid() {
+		return slots
+	}
+
+	ws, we := window.start, window.end
+	search := NewPeriod(ws.Add(-opts.Before), we.Add(opts.After))
+
+	var free *IntervalSet[time.Time]
+	switch {
+	case opts.Busy:
+		free = Union(sets...).Complement(search)
+	case len(sets) == 1:
+		free = sets[0].Overlaps(search)
+	default:
+		free = Intersection(sets...).Overlaps(search)
+	}
+
+	// the free time surrounding each slot, used to rank them
+	room := make([]time.Duration, 0)
+
+	for _, v := range free.intervals {
+		fs, fe := v.Min(), v.Max()
+
+		s := limit.Max(fs.Add(opts.Before), ws)
+		e := limit.Min(fe.Add(-opts.After), we)
+
+		if opts.Step > 0 {
+			origin := time.Date(ws.Year(), ws.Month(), ws.Day(), 0, 0, 0, 0, ws.Location())
+			if r := s.Sub(origin) % opts.Step; r > 0 {
+				s = s.Add(opts.Step - r)
+			} else if r < 0 {
+				s = s.Add(-r)
+			}
+		}
+
+		for !s.Add(d).After(e) {
+			slots = append(slots, NewPeriod(s, s.Add(d)))
+			room = append(room, fe.Sub(fs)-d)
+
+			if opts.Step <= 0 {
+				break
+			}
+			s = s.Add(opts.Step)
+		}
+	}
+
+	if opts.Rank == RankRoomiest {
+		idx := make([]int, len(slots))
+		for i := range idx {
+			idx[i] = i
+		}
+		sort.SliceStable(idx, func(i, j int) bool {
+			return room[idx[i]] > room[idx[j]]
+		})
+
+		ranked := make([]Period[time.Time], len(slots))
+		for i, j := range idx {
+			ranked[i] = slots[j]
+		}
+		slots = ranked
+	}
+
+	if opts.Limit > 0 && len(slots) > opts.Limit {
+		slots = slots[:opts.Limit]
+	}
+
+	return slots
+}
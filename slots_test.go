@@ -0,0 +1,158 @@
+package intervalset
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestFindSlots(t *testing.T) {
+	at := func(h, m int) time.Time {
+		return time.Date(2023, time.December, 1, h, m, 0, 0, time.UTC)
+	}
+
+	free := []*IntervalSet[time.Time]{
+		EmptySet[time.Time]().Add(NewPeriod(at(9, 0), at(12, 0)), NewPeriod(at(14, 0), at(18, 0))),
+		EmptySet[time.Time]().Add(NewPeriod(at(9, 40), at(11, 0)), NewPeriod(at(15, 10), at(17, 0))),
+	}
+
+	busy := []*IntervalSet[time.Time]{
+		EmptySet[time.Time]().Add(NewPeriod(at(12, 0), at(14, 0))),
+		EmptySet[time.Time]().Add(NewPeriod(at(8, 0), at(9, 40)), NewPeriod(at(11, 0), at(15, 10)), NewPeriod(at(17, 0), at(20, 0))),
+	}
+
+	window := NewPeriod(at(8, 0), at(18, 0))
+
+	var table = []struct {
+		sets []*IntervalSet[time.Time]
+		d    time.Duration
+		opts SlotOptions
+		e    []Period[time.Time]
+	}{
+		{
+			free,
+			time.Hour,
+			SlotOptions{},
+			[]Period[time.Time]{
+				NewPeriod(at(9, 40), at(10, 40)),
+				NewPeriod(at(15, 10), at(16, 10)),
+			},
+		},
+		{
+			busy,
+			time.Hour,
+			SlotOptions{Busy: true},
+			[]Period[time.Time]{
+				NewPeriod(at(9, 40), at(10, 40)),
+				NewPeriod(at(15, 10), at(16, 10)),
+			},
+		},
+		{
+			free,
+			time.Hour,
+			SlotOptions{Step: 15 * time.Minute},
+			[]Period[time.Time]{
+				NewPeriod(at(9, 45), at(10, 45)),
+				NewPeriod(at(10, 0), at(11, 0)),
+				NewPeriod(at(15, 15), at(16, 15)),
+				NewPeriod(at(15, 30), at(16, 30)),
+				NewPeriod(at(15, 45), at(16, 45)),
+				NewPeriod(at(16, 0), at(17, 0)),
+			},
+		},
+		{
+			free,
+			time.Hour,
+			SlotOptions{Step: 15 * time.Minute, Before: 10 * time.Minute, After: 10 * time.Minute},
+			[]Period[time.Time]{
+				NewPeriod(at(15, 30), at(16, 30)),
+				NewPeriod(at(15, 45), at(16, 45)),
+			},
+		},
+		{
+			free,
+			30 * time.Minute,
+			SlotOptions{Step: 30 * time.Minute, Rank: RankRoomiest, Limit: 3},
+			[]Period[time.Time]{
+				NewPeriod(at(15, 30), at(16, 0)),
+				NewPeriod(at(16, 0), at(16, 30)),
+				NewPeriod(at(16, 30), at(17, 0)),
+			},
+		},
+		{
+			free,
+			2 * time.Hour,
+			SlotOptions{},
+			[]Period[time.Time]{},
+		},
+		{
+			free[:1],
+			2 * time.Hour,
+			SlotOptions{},
+			[]Period[time.Time]{
+				NewPeriod(at(9, 0), at(11, 0)),
+				NewPeriod(at(14, 0), at(16, 0)),
+			},
+		},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			got := FindSlots(tc.sets, tc.d, window, tc.opts)
+			if len(got) != len(tc.e) {
+				t.Fatalf("expected %d slots, got %d: %v", len(tc.e), len(got), got)
+			}
+			for j, p := range tc.e {
+				if !got[j].Equal(p) {
+					t.Errorf("expected slot %d to be %v, got %v", j, p, got[j])
+				}
+			}
+		})
+	}
+}
+
+func TestFindSlots_WindowBuffers(t *testing.T) {
+	at := func(h, m int) time.Time {
+		return time.Date(2023, time.December, 1, h, m, 0, 0, time.UTC)
+	}
+
+	busy := []*IntervalSet[time.Time]{
+		EmptySet[time.Time]().Add(NewPeriod(at(8, 0), at(9, 0))),
+	}
+
+	// the buffer before the first slot lies outside the window
+	got := FindSlots(busy, time.Hour, NewPeriod(at(9, 0), at(12, 0)), SlotOptions{Busy: true, Before: 15 * time.Minute})
+	if len(got) != 1 || !got[0].Equal(NewPeriod(at(9, 15), at(10, 15))) {
+		t.Errorf("unexpected slots %v", got)
+	}
+
+	got = FindSlots(busy, time.Hour, NewPeriod(at(10, 0), at(12, 0)), SlotOptions{Busy: true, Before: 15 * time.Minute})
+	if len(got) != 1 || !got[0].Equal(NewPeriod(at(10, 0), at(11, 0))) {
+		t.Errorf("unexpected slots %v", got)
+	}
+}
+
+func ExampleFindSlots() {
+	at := func(h, m int) time.Time {
+		return time.Date(2023, time.December, 1, h, m, 0, 0, time.UTC)
+	}
+
+	alice := EmptySet[time.Time]().Add(NewPeriod(at(9, 0), at(10, 20)), NewPeriod(at(13, 0), at(14, 0)))
+	bob := EmptySet[time.Time]().Add(NewPeriod(at(11, 0), at(12, 0)))
+
+	slots := FindSlots(
+		[]*IntervalSet[time.Time]{alice, bob},
+		30*time.Minute,
+		NewPeriod(at(9, 0), at(17, 0)),
+		SlotOptions{Busy: true, Step: 15 * time.Minute, Limit: 3},
+	)
+
+	for _, p := range slots {
+		fmt.Printf("%s - %s\n", p.Min().Format("15:04"), p.Max().Format("15:04"))
+	}
+
+	// Output:
+	// 10:30 - 11:00
+	// 12:00 - 12:30
+	// 12:15 - 12:45
+}
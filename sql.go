@@ -0,0 +1,482 @@
+package intervalset
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidLiteral is returned when scanning a malformed PostgreSQL range or multirange literal.
+var ErrInvalidLiteral = errors.New("intervalset: invalid range literal")
+
+// Value implements the driver.Valuer interface.
+//
+// The range is formatted as a PostgreSQL range literal (e.g. int8range or numrange).
+// Ranges include their lower bound and exclude their upper bound, they are therefore written
+// as "[lower,upper)", which is the canonical form of PostgreSQL discrete ranges. The minimum and maximum values of integer types
+// and infinite floats are written as unbounded limits, and empty ranges as "empty".
+func (p Range[T]) Value() (driver.Value, error) {
+	return formatRange(p.lower, p.upper), nil
+}
+
+// Scan implements the sql.Scanner interface.
+//
+// It parses a PostgreSQL range literal. Integer ranges are canonicalized to an inclusive
+// lower bound and an exclusive upper bound, so "(1,5]" is scanned as the range [2, 6).
+// Unbounded limits are scanned as the minimum and maximum values of integer types and
+// as infinite floats, while empty ranges are scanned as zero value ranges.
+func (p *Range[T]) Scan(src any) error {
+	l, u, err := scanRange[T](src)
+	if err != nil {
+		return err
+	}
+	*p = Range[T]{lower: l, upper: u}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+//
+// The period is formatted as a PostgreSQL tstzrange literal with an inclusive start date and
+// an exclusive end date. The earliest and latest instants representable in Unix nanoseconds
+// are written as unbounded limits, and empty periods as "empty".
+func (p Period[T]) Value() (driver.Value, error) {
+	return formatRange(time.Time(p.start), time.Time(p.end)), nil
+}
+
+// Scan implements the sql.Scanner interface.
+//
+// It parses a PostgreSQL tstzrange literal. Unbounded and infinite limits are scanned as the
+// earliest and latest instants representable in Unix nanoseconds, while empty ranges are
+// scanned as zero value periods.
+func (p *Period[T]) Scan(src any) error {
+	s, e, err := scanRange[time.Time](src)
+	if err != nil {
+		return err
+	}
+	*p = Period[T]{start: T(s), end: T(e)}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// The set is formatted as a PostgreSQL multirange literal (e.g. int8multirange or tstzmultirange),
+// each interval being formatted like Range.Value and Period.Value do.
+// Like MarshalBinary, it rejects sets of named types since Scan cannot build their intervals.
+func (p *IntervalSet[T]) Value() (driver.Value, error) {
+	if !decodable[T]() {
+		var v T
+		return nil, fmt.Errorf("intervalset: cannot format intervals of type %T", v)
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, v := range p.intervals {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(formatRange(v.Min(), v.Max()))
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It parses a PostgreSQL multirange literal and replaces the intervals of the set with the scanned intervals,
+// each of them being scanned like Range.Scan and Period.Scan do. The set keeps its options.
+func (p *IntervalSet[T]) Scan(src any) error {
+	if !decodable[T]() {
+		var v T
+		return fmt.Errorf("intervalset: cannot scan intervals of type %T", v)
+	}
+
+	s, err := literalOf(src)
+	if err != nil {
+		return err
+	}
+
+	l := lexer{src: s}
+	l.skipSpaces()
+	if !l.consume('{') {
+		return l.errorf("expected '{'")
+	}
+
+	s2 := emptySetOf[T](p.config())
+
+	l.skipSpaces()
+	if !l.consume('}') {
+		for {
+			r, err := l.rangeLiteral()
+			if err != nil {
+				return err
+			}
+			lv, uv, empty, err := literalBounds[T](r)
+			if err != nil {
+				return err
+			}
+			if !empty {
+				v, ok := newInterval(lv, uv)
+				if !ok {
+					return fmt.Errorf("intervalset: cannot scan intervals of type %T", lv)
+				}
+				s2.Add(v)
+			}
+
+			l.skipSpaces()
+			if l.consume('}') {
+				break
+			}
+			if !l.consume(',') {
+				return l.errorf("expected ',' or '}'")
+			}
+		}
+	}
+
+	if l.skipSpaces(); l.pos < len(l.src) {
+		return l.errorf("unexpected trailing characters")
+	}
+
+	p.intervals = s2.intervals
+
+	return nil
+}
+
+// scanRange parses a range literal and returns its limits, zero values are returned for empty ranges.
+func scanRange[T any](src any) (T, T, error) {
+	var l, u T
+
+	s, err := literalOf(src)
+	if err != nil {
+		return l, u, err
+	}
+
+	x := lexer{src: s}
+	r, err := x.rangeLiteral()
+	if err != nil {
+		return l, u, err
+	}
+	if x.skipSpaces(); x.pos < len(x.src) {
+		return l, u, x.errorf("unexpected trailing characters")
+	}
+
+	l, u, empty, err := literalBounds[T](r)
+	if err != nil || empty {
+		var z T
+		return z, z, err
+	}
+
+	return l, u, nil
+}
+
+func literalOf(src any) (string, error) {
+	switch v := src.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	}
+	return "", fmt.Errorf("intervalset: cannot scan %T into a range", src)
+}
+
+// formatRange formats the limits as a range literal.
+func formatRange[T any](l, u T) string {
+	if isEmpty(l, u) {
+		return "empty"
+	}
+
+	lo, hi := unbounded[T]()
+
+	var b strings.Builder
+	b.WriteByte('[')
+	if !isEqual(l, lo) {
+		b.WriteString(formatValue(l))
+	}
+	b.WriteByte(',')
+	if !isEqual(u, hi) {
+		b.WriteString(formatValue(u))
+	}
+	b.WriteByte(')')
+
+	return b.String()
+}
+
+func formatValue[T any](v T) string {
+	if t, ok := any(v).(time.Time); ok {
+		return `"` + t.Format("2006-01-02 15:04:05.999999999-07:00") + `"`
+	}
+	return fmt.Sprint(v)
+}
+
+// unbounded returns the values representing unbounded limits of type T.
+func unbounded[T any]() (T, T) {
+	var l, u T
+	if _, ok := any(l).(time.Time); ok {
+		return any(time.Unix(0, math.MinInt64).UTC()).(T), any(time.Unix(0, math.MaxInt64).UTC()).(T)
+	}
+
+	lr, ur := reflect.ValueOf(&l).Elem(), reflect.ValueOf(&u).Elem()
+	switch lr.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b := lr.Type().Bits()
+		lr.SetInt(math.MinInt64 >> (64 - b))
+		ur.SetInt(math.MaxInt64 >> (64 - b))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		ur.SetUint(math.MaxUint64 >> (64 - ur.Type().Bits()))
+	case reflect.Float32, reflect.Float64:
+		lr.SetFloat(math.Inf(-1))
+		ur.SetFloat(math.Inf(1))
+	}
+
+	return l, u
+}
+
+func isEqual[T any](a, b T) bool {
+	if t, ok := any(a).(time.Time); ok {
+		return t.Equal(any(b).(time.Time))
+	}
+	return any(a) == any(b)
+}
+
+func isEmpty[T any](l, u T) bool {
+	if t, ok := any(l).(time.Time); ok {
+		return !t.Before(any(u).(time.Time))
+	}
+	lr, ur := reflect.ValueOf(l), reflect.ValueOf(u)
+	switch lr.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return lr.Int() >= ur.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return lr.Uint() >= ur.Uint()
+	case reflect.Float32, reflect.Float64:
+		return !(lr.Float() < ur.Float())
+	}
+	return false
+}
+
+// rangeLiteral is a parsed range literal.
+type rangeLiteral struct {
+	empty          bool
+	lower, upper   string
+	lowerInf       bool
+	upperInf       bool
+	lowerInclusive bool
+	upperInclusive bool
+}
+
+// literalBounds returns the limits of the range literal as values of type T.
+// It reports whether the range is empty.
+// Discrete ranges are canonicalized to an inclusive lower bound and an exclusive upper bound.
+func literalBounds[T any](r rangeLiteral) (T, T, bool, error) {
+	lo, hi := unbounded[T]()
+	if r.empty {
+		return lo, hi, true, nil
+	}
+
+	l, u := lo, hi
+	if !r.lowerInf {
+		v, err := parseValue[T](r.lower)
+		if err != nil {
+			return lo, hi, false, err
+		}
+		l = v
+	}
+	if !r.upperInf {
+		v, err := parseValue[T](r.upper)
+		if err != nil {
+			return lo, hi, false, err
+		}
+		u = v
+	}
+
+	// like PostgreSQL, the bounds are compared before the canonicalization:
+	// "[5,4]" is rejected while "(5,5)" is empty.
+	if !r.lowerInf && !r.upperInf && isEmpty(l, u) && !isEqual(l, u) {
+		return lo, hi, false, fmt.Errorf("%w: lower bound %s greater than upper bound %s", ErrInvalidLiteral, r.lower, r.upper)
+	}
+
+	if k := kindOf[T](); k == kindInt || k == kindUint {
+		if !r.lowerInf && !r.lowerInclusive && !isEqual(l, hi) {
+			l = increment(l)
+		}
+		if !r.upperInf && r.upperInclusive && !isEqual(u, hi) {
+			u = increment(u)
+		}
+	}
+
+	if isEmpty(l, u) {
+		return lo, hi, true, nil
+	}
+
+	return l, u, false, nil
+}
+
+func increment[T any](v T) T {
+	r := reflect.ValueOf(&v).Elem()
+	switch r.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		r.SetInt(r.Int() + 1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		r.SetUint(r.Uint() + 1)
+	}
+	return v
+}
+
+// timeLayouts are the layouts accepted when parsing timestamps.
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999-07:00:00",
+	time.RFC3339Nano,
+}
+
+func parseValue[T any](s string) (T, error) {
+	var v T
+
+	if _, ok := any(v).(time.Time); ok {
+		lo, hi := unbounded[T]()
+		switch s {
+		case "-infinity":
+			return lo, nil
+		case "infinity":
+			return hi, nil
+		}
+		for _, l := range timeLayouts {
+			if t, err := time.Parse(l, s); err == nil {
+				return any(t).(T), nil
+			}
+		}
+		return v, fmt.Errorf("%w: invalid time %q", ErrInvalidLiteral, s)
+	}
+
+	r := reflect.ValueOf(&v).Elem()
+	switch r.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, r.Type().Bits())
+		if err != nil {
+			return v, fmt.Errorf("%w: %w", ErrInvalidLiteral, err)
+		}
+		r.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, r.Type().Bits())
+		if err != nil {
+			return v, fmt.Errorf("%w: %w", ErrInvalidLiteral, err)
+		}
+		r.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, r.Type().Bits())
+		if err != nil || math.IsNaN(n) {
+			return v, fmt.Errorf("%w: invalid number %q", ErrInvalidLiteral, s)
+		}
+		r.SetFloat(n)
+	default:
+		return v, fmt.Errorf("intervalset: cannot scan values of type %T", v)
+	}
+
+	return v, nil
+}
+
+// lexer reads range and multirange literals.
+type lexer struct {
+	src string
+	pos int
+}
+
+func (l *lexer) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w at position %d: %s", ErrInvalidLiteral, l.pos, fmt.Sprintf(format, args...))
+}
+
+func (l *lexer) skipSpaces() {
+	for l.pos < len(l.src) && strings.IndexByte(" \t\n\r", l.src[l.pos]) >= 0 {
+		l.pos++
+	}
+}
+
+func (l *lexer) consume(c byte) bool {
+	if l.pos < len(l.src) && l.src[l.pos] == c {
+		l.pos++
+		return true
+	}
+	return false
+}
+
+// rangeLiteral reads a range literal such as "[1,5)", "(,10]" or "empty".
+func (l *lexer) rangeLiteral() (rangeLiteral, error) {
+	var r rangeLiteral
+
+	l.skipSpaces()
+	if len(l.src)-l.pos >= 5 && strings.EqualFold(l.src[l.pos:l.pos+5], "empty") {
+		l.pos += 5
+		r.empty = true
+		return r, nil
+	}
+
+	switch {
+	case l.consume('['):
+		r.lowerInclusive = true
+	case l.consume('('):
+	default:
+		return r, l.errorf("expected '[', '(' or empty")
+	}
+
+	var err error
+	if r.lower, r.lowerInf, err = l.bound(','); err != nil {
+		return r, err
+	}
+	if !l.consume(',') {
+		return r, l.errorf("expected ','")
+	}
+	if r.upper, r.upperInf, err = l.bound(')', ']'); err != nil {
+		return r, err
+	}
+
+	switch {
+	case l.consume(']'):
+		r.upperInclusive = true
+	case l.consume(')'):
+	default:
+		return r, l.errorf("expected ']' or ')'")
+	}
+
+	return r, nil
+}
+
+// bound reads a range bound up to one of the given delimiters.
+// It reports whether the bound is omitted, meaning the range is unbounded.
+func (l *lexer) bound(delims ...byte) (string, bool, error) {
+	var b strings.Builder
+
+	start := l.pos
+	quoted := false
+
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '"':
+			// a doubled quote within a quoted value is a literal quote
+			if quoted && l.pos+1 < len(l.src) && l.src[l.pos+1] == '"' {
+				b.WriteByte('"')
+				l.pos += 2
+				continue
+			}
+			quoted = !quoted
+			l.pos++
+			continue
+		case c == '\\':
+			if l.pos+1 >= len(l.src) {
+				return "", false, l.errorf("unexpected end of input")
+			}
+			b.WriteByte(l.src[l.pos+1])
+			l.pos += 2
+			continue
+		case !quoted && strings.IndexByte(string(delims), c) >= 0:
+			return strings.TrimSpace(b.String()), l.pos == start, nil
+		}
+		b.WriteByte(c)
+		l.pos++
+	}
+
+	return "", false, l.errorf("unexpected end of input")
+}
@@ -0,0 +1,363 @@
+package intervalset
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+var (
+	_ driver.Valuer = Range[int]{}
+	_ driver.Valuer = Period[time.Time]{}
+	_ driver.Valuer = EmptySet[int]()
+	_ sql.Scanner   = &Range[int]{}
+	_ sql.Scanner   = &Period[time.Time]{}
+	_ sql.Scanner   = EmptySet[int]()
+)
+
+func TestRange_Value(t *testing.T) {
+	var table = []struct {
+		e string
+		r Range[int64]
+	}{
+		{"[1,5)", NewRange[int64](1, 5)},
+		{"[-10,0)", NewRange[int64](-10, 0)},
+		{"empty", NewRange[int64](5, 5)},
+		{"empty", Range[int64]{}},
+		{"[,5)", NewRange[int64](math.MinInt64, 5)},
+		{"[1,)", NewRange[int64](1, math.MaxInt64)},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			v, err := tc.r.Value()
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if v != tc.e {
+				t.Errorf("expected %q, got %q", tc.e, v)
+			}
+		})
+	}
+}
+
+func TestRange_Scan(t *testing.T) {
+	var table = []struct {
+		s string
+		e Range[int64]
+	}{
+		{"[1,5)", NewRange[int64](1, 5)},
+		{"[1,5]", NewRange[int64](1, 6)},
+		{"(1,5]", NewRange[int64](2, 6)},
+		{"(1,5)", NewRange[int64](2, 5)},
+		{` ["1","5") `, NewRange[int64](1, 5)},
+		{"(,5)", NewRange[int64](math.MinInt64, 5)},
+		{"[1,)", NewRange[int64](1, math.MaxInt64)},
+		{"(,)", NewRange[int64](math.MinInt64, math.MaxInt64)},
+		{"empty", Range[int64]{}},
+		{"EMPTY", Range[int64]{}},
+		{"(1,2)", Range[int64]{}},
+		{"[3,3)", Range[int64]{}},
+		{"(5,5)", Range[int64]{}},
+		{"(5,5]", Range[int64]{}},
+		{"[5,5]", NewRange[int64](5, 6)},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			var r Range[int64]
+			if err := r.Scan(tc.s); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if r != tc.e {
+				t.Errorf("expected %v, got %v", tc.e, r)
+			}
+
+			var b Range[int64]
+			if err := b.Scan([]byte(tc.s)); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if b != tc.e {
+				t.Errorf("expected %v, got %v", tc.e, b)
+			}
+		})
+	}
+}
+
+func TestRange_ScanFloat(t *testing.T) {
+	var table = []struct {
+		s string
+		e Range[float64]
+	}{
+		{"[1.5,5.25)", NewRange(1.5, 5.25)},
+		{"(1.5,5.25]", NewRange(1.5, 5.25)},
+		{"(,0.5]", NewRange(math.Inf(-1), 0.5)},
+		{"[0.5,infinity)", NewRange(0.5, math.Inf(1))},
+		{"empty", Range[float64]{}},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			var r Range[float64]
+			if err := r.Scan(tc.s); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if r != tc.e {
+				t.Errorf("expected %v, got %v", tc.e, r)
+			}
+
+			v, err := r.Value()
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			var r2 Range[float64]
+			if err := r2.Scan(v); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if r2 != r {
+				t.Errorf("expected %v to round trip, got %v", r, r2)
+			}
+		})
+	}
+}
+
+func TestRange_ScanInvalid(t *testing.T) {
+	var table = []struct {
+		s any
+	}{
+		{""},
+		{"[1,5"},
+		{"[1;5)"},
+		{"1,5"},
+		{"[a,5)"},
+		{"[5,1)"},
+		{"[5,4]"},
+		{"(5,4]"},
+		{"[1,5) x"},
+		{`["1,5)`},
+		{"[1,300)"},
+		{"[NaN,1)"},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			var r Range[int8]
+			if err := r.Scan(tc.s); !errors.Is(err, ErrInvalidLiteral) {
+				t.Errorf("expected an invalid literal error, got %v", err)
+			}
+		})
+	}
+
+	var r Range[int8]
+	if err := r.Scan(10); err == nil {
+		t.Errorf("expected an error when scanning an unsupported type")
+	}
+}
+
+func TestPeriod_ValueAndScan(t *testing.T) {
+	p := NewPeriod(
+		time.Date(2023, time.December, 1, 8, 0, 0, 0, time.UTC),
+		time.Date(2023, time.December, 1, 12, 30, 0, 500, time.FixedZone("", 2*60*60)),
+	)
+
+	v, err := p.Value()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	e := `["2023-12-01 08:00:00+00:00","2023-12-01 12:30:00.0000005+02:00")`
+	if v != e {
+		t.Errorf("expected %q, got %q", e, v)
+	}
+
+	var p2 Period[time.Time]
+	if err := p2.Scan(v); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !p2.Equal(p) {
+		t.Errorf("expected %v, got %v", p, p2)
+	}
+
+	var table = []struct {
+		s string
+		e Period[time.Time]
+	}{
+		{
+			`["2023-12-01 08:00:00+00","2023-12-01 12:00:00+01")`,
+			NewPeriod(
+				time.Date(2023, time.December, 1, 8, 0, 0, 0, time.UTC),
+				time.Date(2023, time.December, 1, 11, 0, 0, 0, time.UTC),
+			),
+		},
+		{
+			`[2023-12-01T08:00:00Z,infinity)`,
+			NewPeriod(
+				time.Date(2023, time.December, 1, 8, 0, 0, 0, time.UTC),
+				time.Unix(0, math.MaxInt64),
+			),
+		},
+		{
+			`(,"2023-12-01 08:00:00.123456+05:30"]`,
+			NewPeriod(
+				time.Unix(0, math.MinInt64),
+				time.Date(2023, time.December, 1, 2, 30, 0, 123456000, time.UTC),
+			),
+		},
+		{
+			`empty`,
+			Period[time.Time]{},
+		},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			var p Period[time.Time]
+			if err := p.Scan(tc.s); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !p.Equal(tc.e) {
+				t.Errorf("expected %v, got %v", tc.e, p)
+			}
+		})
+	}
+}
+
+func TestRangeSet_ValueAndScan(t *testing.T) {
+	var table = []struct {
+		s string
+		e *IntervalSet[int]
+		v string
+	}{
+		{
+			"{}",
+			EmptySet[int](),
+			"{}",
+		},
+		{
+			"{[1,3), [5,7], empty, (8,10)}",
+			EmptySet[int]().Add(NewRange(1, 3), NewRange(5, 8), NewRange(9, 10)),
+			"{[1,3),[5,8),[9,10)}",
+		},
+		{
+			"{[5,7),[1,5)}",
+			EmptySet[int]().Add(NewRange(1, 7)),
+			"{[1,7)}",
+		},
+		{
+			"{(,0),[10,)}",
+			EmptySet[int]().Add(NewRange(math.MinInt, 0), NewRange(10, math.MaxInt)),
+			"{[,0),[10,)}",
+		},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			s := EmptySet[int]()
+			if err := s.Scan(tc.s); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !s.Equal(tc.e) {
+				t.Errorf("both sets should be equal, expected %v, got %v", tc.e.AsSlice(), s.AsSlice())
+			}
+
+			v, err := s.Value()
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if v != tc.v {
+				t.Errorf("expected %q, got %q", tc.v, v)
+			}
+		})
+	}
+}
+
+func TestPeriodSet_ValueAndScan(t *testing.T) {
+	s1 := EmptySet[time.Time]().Add(
+		NewPeriod(
+			time.Date(2023, time.December, 1, 8, 0, 0, 0, time.UTC),
+			time.Date(2023, time.December, 1, 12, 0, 0, 0, time.UTC),
+		),
+		NewPeriod(
+			time.Date(2023, time.December, 2, 8, 0, 0, 0, time.UTC),
+			time.Date(2023, time.December, 2, 12, 0, 0, 0, time.UTC),
+		),
+	)
+
+	v, err := s1.Value()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	s2 := EmptySet[time.Time]()
+	if err := s2.Scan(v); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if !s1.Equal(s2) {
+		t.Errorf("both sets should be equal, expected %v, got %v", s1.AsSlice(), s2.AsSlice())
+	}
+}
+
+func TestRangeSet_ScanInvalid(t *testing.T) {
+	var table = []struct {
+		s string
+	}{
+		{""},
+		{"{"},
+		{"[1,2)"},
+		{"{[1,2)"},
+		{"{[1,2) [3,4)}"},
+		{"{[1,2)}}"},
+		{"{[2,1)}"},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			s := EmptySet[int]().Add(NewRange(1, 2))
+			if err := s.Scan(tc.s); !errors.Is(err, ErrInvalidLiteral) {
+				t.Errorf("expected an invalid literal error, got %v", err)
+			}
+			if !s.Equal(EmptySet[int]().Add(NewRange(1, 2))) {
+				t.Errorf("expected the set to be left untouched, got %v", s.AsSlice())
+			}
+		})
+	}
+}
+
+func TestRangeSet_ValueAndScanNamedType(t *testing.T) {
+	s := EmptySet[port]().Add(NewRange[port](1, 5))
+
+	// the literal could not be scanned back, so it must not be formatted either
+	if v, err := s.Value(); err == nil {
+		t.Errorf("expected an error formatting a set of a named type, got %v", v)
+	}
+	if err := s.Scan("{[1,5)}"); err == nil {
+		t.Error("expected an error scanning a set of a named type")
+	}
+	if !s.Equal(EmptySet[port]().Add(NewRange[port](1, 5))) {
+		t.Errorf("expected the set to be left untouched, got %v", s.AsSlice())
+	}
+
+	// ranges of named types are supported
+	var r Range[port]
+	if err := r.Scan("[1,5)"); err != nil || r != NewRange[port](1, 5) {
+		t.Errorf("expected [1, 5), got %v, %v", r, err)
+	}
+}
+
+func ExampleRange_Scan() {
+	var r Range[int64]
+	_ = r.Scan("(1,5]")
+
+	v, _ := r.Value()
+
+	fmt.Println(r.Min(), r.Max(), v)
+
+	// Output:
+	// 2 6 [2,6)
+}
@@ -0,0 +1,169 @@
+package intervalset
+
+import "sync"
+
+// EmptySyncSet returns an empty set safe for concurrent use.
+func EmptySyncSet[T any]() *SyncIntervalSet[T] {
+	return &SyncIntervalSet[T]{set: EmptySet[T]()}
+}
+
+// SyncIntervalSet is an ordered set of intervals safe for concurrent use by multiple goroutines.
+// It offers the same methods as IntervalSet, guarded by a sync.RWMutex, along with atomic
+// compound operations. The sets returned by its methods are copies the caller owns.
+type SyncIntervalSet[T any] struct {
+	mu  sync.RWMutex
+	set *IntervalSet[T]
+}
+
+// Sync returns a new set safe for concurrent use containing the intervals of the set
+// and configured with the same options.
+func (p *IntervalSet[T]) Sync() *SyncIntervalSet[T] {
+	s := emptySetOf[T](p.config())
+	s.intervals = append(s.intervals, p.intervals...)
+	return &SyncIntervalSet[T]{set: s}
+}
+
+// Snapshot returns a new set containing the intervals of the set at the time of the call
+// and configured with the same options.
+func (p *SyncIntervalSet[T]) Snapshot() *IntervalSet[T] {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	s := emptySetOf[T](p.set.config())
+	s.intervals = append(s.intervals, p.set.intervals...)
+	return s
+}
+
+// AsSlice returns a copy of the underlying set of intervals as a slice.
+func (p *SyncIntervalSet[T]) AsSlice() []Interval[T] {
+	return p.Snapshot().intervals
+}
+
+// IsEmpty reports whether the set is empty.
+func (p *SyncIntervalSet[T]) IsEmpty() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.set.IsEmpty()
+}
+
+// Equal reports whether the set is equal to another set.
+func (p *SyncIntervalSet[T]) Equal(q *IntervalSet[T]) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.set.Equal(q)
+}
+
+// Add adds the given interval to the set.
+// Intervals will be merged with the intervals present in the set.
+func (p *SyncIntervalSet[T]) Add(intervals ...Interval[T]) *SyncIntervalSet[T] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.set.Add(intervals...)
+	return p
+}
+
+// Sub subtracts the given intervals from the set.
+func (p *SyncIntervalSet[T]) Sub(intervals ...Interval[T]) *SyncIntervalSet[T] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.set.Sub(intervals...)
+	return p
+}
+
+// AddDiff adds the given intervals to the set like Add does
+// and returns a new set containing the newly covered intervals.
+func (p *SyncIntervalSet[T]) AddDiff(intervals ...Interval[T]) *IntervalSet[T] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.set.AddDiff(intervals...)
+}
+
+// SubDiff subtracts the given intervals from the set like Sub does
+// and returns a new set containing the newly uncovered intervals.
+func (p *SyncIntervalSet[T]) SubDiff(intervals ...Interval[T]) *IntervalSet[T] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.set.SubDiff(intervals...)
+}
+
+// OnChange registers a function invoked with the newly covered and the newly uncovered
+// intervals each time the set is modified, see IntervalSet.OnChange. The function is
+// invoked while the set is locked, so it must not call the methods of the set.
+func (p *SyncIntervalSet[T]) OnChange(f func(added, removed *IntervalSet[T])) *SyncIntervalSet[T] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.set.OnChange(f)
+	return p
+}
+
+// TryAdd adds q to the set only if it does not overlap any interval of the set,
+// as a single atomic operation. Intervals merely adjoining q are not considered overlapping.
+// It returns the intervals of the set overlapping q and reports whether q was added.
+func (p *SyncIntervalSet[T]) TryAdd(q Interval[T]) ([]Interval[T], bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.set.TryAdd(q)
+}
+
+// AddIfFree adds q to the set only if it does not overlap any interval of the set,
+// as a single atomic operation, and reports whether q was added.
+// Use TryAdd to get the intervals conflicting with q.
+func (p *SyncIntervalSet[T]) AddIfFree(q Interval[T]) bool {
+	_, ok := p.TryAdd(q)
+	return ok
+}
+
+// Overlaps returns a new set containing the intervals overlapping q.
+func (p *SyncIntervalSet[T]) Overlaps(q Interval[T]) *IntervalSet[T] {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.set.Overlaps(q)
+}
+
+// IsSubset reports whether s is a subset of p.
+func (p *SyncIntervalSet[T]) IsSubset(s *IntervalSet[T]) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.set.IsSubset(s)
+}
+
+// Complement returns a new set containing the intervals in q that are not in p.
+func (p *SyncIntervalSet[T]) Complement(q Interval[T]) *IntervalSet[T] {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.set.Complement(q)
+}
+
+// Difference returns a new set containing the intervals in p that are not in q.
+func (p *SyncIntervalSet[T]) Difference(q *IntervalSet[T]) *IntervalSet[T] {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.set.Difference(q)
+}
+
+// Iter iterates over a snapshot of the set and pass intervals to the anonymous function.
+// It stops when the function returns false or when there are no more intervals to consume.
+// Since it iterates over a snapshot, the function may modify the set.
+func (p *SyncIntervalSet[T]) Iter(f func(Interval[T]) bool) {
+	p.Snapshot().Iter(f)
+}
+
+// IterBetween iterates over a snapshot of the set between the given interval.
+// Each interval is truncated to fit within q and pass intervals to the anonymous function.
+// It stops when the function returns false or when there are no more intervals to consume.
+// Since it iterates over a snapshot, the function may modify the set.
+func (p *SyncIntervalSet[T]) IterBetween(q Interval[T], f func(Interval[T]) bool) {
+	p.Overlaps(q).Iter(f)
+}
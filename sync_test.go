@@ -0,0 +1,150 @@
+package intervalset
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// These tests are meant to be run with the race detector: go test -race
+
+func TestSyncIntervalSet_AddIfFree(t *testing.T) {
+	s := EmptySyncSet[int]().Add(NewRange(0, 10))
+
+	var ok atomic.Int32
+	var wg sync.WaitGroup
+
+	// every goroutine tries to reserve the same slot, only one of them must succeed
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if s.AddIfFree(NewRange(20, 30)) {
+				ok.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := ok.Load(); n != 1 {
+		t.Errorf("expected exactly one reservation to succeed, got %d", n)
+	}
+
+	if s.AddIfFree(NewRange(5, 15)) {
+		t.Errorf("expected an overlapping reservation to fail")
+	}
+	if !s.AddIfFree(NewRange(10, 15)) {
+		t.Errorf("expected an adjoining reservation to succeed")
+	}
+
+	expected := genExpectedRangeSet([]Interval[int]{NewRange(0, 15), NewRange(20, 30)})
+	if !s.Equal(expected) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, s.AsSlice())
+	}
+}
+
+func TestSyncIntervalSet_ConcurrentReadsAndWrites(t *testing.T) {
+	s := EmptySyncSet[time.Time]()
+
+	start := time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC)
+	window := NewPeriod(start, start.Add(24*time.Hour))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				s0 := start.Add(time.Duration(i*50+j) * time.Minute)
+				p := NewPeriod(s0, s0.Add(time.Minute))
+				s.Add(p)
+				if j%10 == 0 {
+					s.Sub(p)
+				}
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_ = s.Overlaps(window)
+				_ = s.Complement(window)
+				_ = s.IsEmpty()
+				s.Iter(func(i Interval[time.Time]) bool {
+					return true
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 400 minutes were added, 40 of them were removed
+	if d := Duration(s.Snapshot()); d != 360*time.Minute {
+		t.Errorf("expected 360 minutes to be reserved, got %s", d)
+	}
+}
+
+func TestSyncIntervalSet_Snapshot(t *testing.T) {
+	s := EmptySet[int]().Add(NewRange(1, 3)).Sync()
+
+	snapshot := s.Snapshot()
+	s.Add(NewRange(5, 7))
+
+	if !snapshot.Equal(genExpectedRangeSet([]Interval[int]{NewRange(1, 3)})) {
+		t.Errorf("expected the snapshot to be left untouched, got %v", snapshot.AsSlice())
+	}
+
+	// the function may modify the set while iterating
+	s.Iter(func(i Interval[int]) bool {
+		s.Sub(i)
+		return true
+	})
+
+	if !s.IsEmpty() {
+		t.Errorf("expected the set to be empty, got %v", s.AsSlice())
+	}
+}
+
+func TestSyncIntervalSet_TryAdd(t *testing.T) {
+	s := EmptySyncSet[int]().Add(NewRange(0, 10), NewRange(20, 30))
+
+	conflicts, ok := s.TryAdd(NewRange(5, 25))
+	if ok {
+		t.Errorf("expected an overlapping interval to be rejected")
+	}
+
+	expected := []Interval[int]{NewRange(0, 10), NewRange(20, 30)}
+	if !genExpectedRangeSet(conflicts).Equal(genExpectedRangeSet(expected)) {
+		t.Errorf("expected the conflicts %v, got %v", expected, conflicts)
+	}
+
+	if conflicts, ok := s.TryAdd(NewRange(10, 20)); !ok || len(conflicts) != 0 {
+		t.Errorf("expected an adjoining interval to be added, got %v", conflicts)
+	}
+}
+
+func TestSyncIntervalSet_OnChange(t *testing.T) {
+	var added, removed []Interval[int]
+
+	s := EmptySyncSet[int]().OnChange(func(a, r *IntervalSet[int]) {
+		added = append(added, a.AsSlice()...)
+		removed = append(removed, r.AsSlice()...)
+	})
+
+	s.Add(NewRange(0, 10))
+	if d := s.AddDiff(NewRange(5, 15)); !d.Equal(genExpectedRangeSet([]Interval[int]{NewRange(10, 15)})) {
+		t.Errorf("expected the newly covered intervals, got %v", d.AsSlice())
+	}
+	if d := s.SubDiff(NewRange(12, 20)); !d.Equal(genExpectedRangeSet([]Interval[int]{NewRange(12, 15)})) {
+		t.Errorf("expected the newly uncovered intervals, got %v", d.AsSlice())
+	}
+
+	expected := []Interval[int]{NewRange(0, 10), NewRange(10, 15)}
+	if !genExpectedRangeSet(added).Equal(genExpectedRangeSet(expected)) {
+		t.Errorf("expected the added intervals %v, got %v", expected, added)
+	}
+	if !genExpectedRangeSet(removed).Equal(genExpectedRangeSet([]Interval[int]{NewRange(12, 15)})) {
+		t.Errorf("expected the removed intervals [12, 15], got %v", removed)
+	}
+}
@@ -0,0 +1,75 @@
+package intervalset
+
+import (
+	"math"
+	"sort"
+)
+
+// WithTolerance makes a set of floats consider bounds within eps of each other as equal.
+// The bounds of the intervals given to Add, Sub and TryAdd are snapped to the closest bound
+// of the set within eps, so intervals separated by less than eps are merged, and Equal and
+// IsSubset compare bounds with the same tolerance. Intervals shorter than eps are ignored.
+// It has no effect on sets of other types.
+func WithTolerance(eps float64) SetOption {
+	return func(c *setConfig) {
+		c.tolerance = eps
+	}
+}
+
+// prepare returns the interval to add or subtract according to the policy and the tolerance of the set,
+// and reports whether there is one. Empty intervals, such as [+Inf, +Inf], cover nothing and are dropped.
+func (p *IntervalSet[T]) prepare(q Interval[T]) (Interval[T], bool) {
+	q, ok := sanitize(p.invalid, q)
+	if !ok || isEmptyInterval(q) {
+		return q, false
+	}
+	if p.tolerance <= 0 {
+		return q, true
+	}
+	return p.snap(q)
+}
+
+// snap returns q with its bounds replaced by the closest bounds of the set within the tolerance.
+// It reports false when q is shorter than the tolerance or when snapping collapsed it.
+func (p *IntervalSet[T]) snap(q Interval[T]) (Interval[T], bool) {
+	l, u := p.snapValue(q.Min()), p.snapValue(q.Max())
+	if isEmpty(l, u) || position(u)-position(l) <= p.tolerance {
+		return q, false
+	}
+	if isEqual(l, q.Min()) && isEqual(u, q.Max()) {
+		return q, true
+	}
+	if i, ok := newInterval(l, u); ok {
+		return i, true
+	}
+	return q, true
+}
+
+// snapValue returns the bound of the set closest to v within the tolerance, v when there is none.
+func (p *IntervalSet[T]) snapValue(v T) T {
+	// the bounds of the set are ordered: min0 <= max0 <= min1 <= max1...
+	bound := func(i int) T {
+		if i%2 == 0 {
+			return p.intervals[i/2].Min()
+		}
+		return p.intervals[i/2].Max()
+	}
+
+	x := position(v)
+	i := sort.Search(2*len(p.intervals), func(i int) bool {
+		return position(bound(i)) >= x-p.tolerance
+	})
+
+	snapped, d := v, p.tolerance
+	for ; i < 2*len(p.intervals) && position(bound(i)) <= x+p.tolerance; i++ {
+		if e := math.Abs(position(bound(i)) - x); e <= d {
+			snapped, d = bound(i), e
+		}
+	}
+	return snapped
+}
+
+// near reports whether a and b are equal within the tolerance.
+func near[T any](a, b T, eps float64) bool {
+	return isEqual(a, b) || math.Abs(position(a)-position(b)) <= eps
+}
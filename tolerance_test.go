@@ -0,0 +1,126 @@
+package intervalset
+
+import (
+	"fmt"
+	"testing"
+)
+
+func genExpectedFloatSet(p []Interval[float64]) *IntervalSet[float64] {
+	return &IntervalSet[float64]{intervals: p}
+}
+
+func TestIntervalSet_WithTolerance(t *testing.T) {
+	const eps = 1e-9
+
+	var table = []struct {
+		add      []Interval[float64]
+		sub      []Interval[float64]
+		expected *IntervalSet[float64]
+	}{
+		{
+			[]Interval[float64]{NewRange(0, 0.30000000000000004), NewRange(0.3, 1)},
+			nil,
+			genExpectedFloatSet([]Interval[float64]{NewRange(0, 1.0)}),
+		},
+		{
+			[]Interval[float64]{NewRange(0.3, 1), NewRange(0, 0.30000000000000004)},
+			nil,
+			genExpectedFloatSet([]Interval[float64]{NewRange(0, 1.0)}),
+		},
+		{
+			// intervals further apart are left untouched
+			[]Interval[float64]{NewRange(0, 0.3), NewRange(0.3001, 1)},
+			nil,
+			genExpectedFloatSet([]Interval[float64]{NewRange(0, 0.3), NewRange(0.3001, 1)}),
+		},
+		{
+			// subtracting up to a bound within eps does not remove slivers
+			[]Interval[float64]{NewRange(0, 1.0)},
+			[]Interval[float64]{NewRange(-1, 1e-12), NewRange(1-1e-12, 2)},
+			genExpectedFloatSet([]Interval[float64]{NewRange(0, 1.0)}),
+		},
+		{
+			// intervals shorter than eps are ignored
+			[]Interval[float64]{NewRange(0, 1.0), NewRange(5, 5+1e-12)},
+			[]Interval[float64]{NewRange(0.5, 0.5+1e-12)},
+			genExpectedFloatSet([]Interval[float64]{NewRange(0, 1.0)}),
+		},
+		{
+			[]Interval[float64]{NewRange(0, 1.0), NewRange(2, 3.0)},
+			[]Interval[float64]{NewRange(1+1e-12, 2-1e-12)},
+			genExpectedFloatSet([]Interval[float64]{NewRange(0, 1.0), NewRange(2, 3.0)}),
+		},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			s := EmptySet[float64](WithTolerance(eps)).Add(tc.add...).Sub(tc.sub...)
+
+			// compare exactly to make sure no sliver is left
+			if !tc.expected.Equal(&IntervalSet[float64]{intervals: s.intervals}) {
+				t.Errorf("both sets should be equal, expected %v, got %v", tc.expected, s.AsSlice())
+			}
+		})
+	}
+}
+
+func TestIntervalSet_WithToleranceEqualAndSubset(t *testing.T) {
+	s := EmptySet[float64](WithTolerance(1e-9)).Add(NewRange(0, 0.30000000000000004), NewRange(0.5, 1))
+
+	expected := genExpectedFloatSet([]Interval[float64]{NewRange(0, 0.3), NewRange(0.5, 1)})
+	if !s.Equal(expected) || !expected.Equal(s) {
+		t.Errorf("both sets should be equal within the tolerance")
+	}
+	if EmptySet[float64]().Add(NewRange(0, 0.30000000000000004)).Equal(genExpectedFloatSet([]Interval[float64]{NewRange(0, 0.3)})) {
+		t.Errorf("both sets should differ without tolerance")
+	}
+
+	if !s.IsSubset(genExpectedFloatSet([]Interval[float64]{NewRange(0.1, 0.3)})) {
+		t.Errorf("expected the set to contain [0.1, 0.3] within the tolerance")
+	}
+	if s.IsSubset(genExpectedFloatSet([]Interval[float64]{NewRange(0.1, 0.31)})) {
+		t.Errorf("expected the set not to contain [0.1, 0.31]")
+	}
+
+	// the tolerance has no effect on integers
+	i := EmptySet[int](WithTolerance(10)).Add(NewRange(0, 1), NewRange(3, 4))
+	if len(i.AsSlice()) != 2 {
+		t.Errorf("expected the ranges not to be merged, got %v", i.AsSlice())
+	}
+}
+
+func TestIntervalSet_WithToleranceDifferenceAndComplement(t *testing.T) {
+	s := EmptySet[float64](WithTolerance(1e-6)).Add(NewRange(0, 1.0))
+
+	// the derived sets compare the bounds with the tolerance of s, no sliver is left
+	d := s.Difference(EmptySet[float64]().Add(NewRange(0.5, 0.9999999999)))
+	expected := genExpectedFloatSet([]Interval[float64]{NewRange(0, 0.5)})
+	if !expected.Equal(&IntervalSet[float64]{intervals: d.intervals}) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, d.AsSlice())
+	}
+
+	if c := s.Complement(NewRange(0, 1.0000000001)); !c.IsEmpty() {
+		t.Errorf("expected an empty complement, got %v", c.AsSlice())
+	}
+
+	c := s.Complement(NewRange(-1, 1.0000000001))
+	expected = genExpectedFloatSet([]Interval[float64]{NewRange(-1, 0.0)})
+	if !expected.Equal(&IntervalSet[float64]{intervals: c.intervals}) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, c.AsSlice())
+	}
+
+	u := Union(s, EmptySet[float64]().Add(NewRange(1.0000000001, 2)))
+	expected = genExpectedFloatSet([]Interval[float64]{NewRange(0, 2.0)})
+	if !expected.Equal(&IntervalSet[float64]{intervals: u.intervals}) {
+		t.Errorf("both sets should be equal, expected %v, got %v", expected, u.AsSlice())
+	}
+}
+
+func ExampleWithTolerance() {
+	s := EmptySet[float64](WithTolerance(1e-9)).Add(NewRange(0, 0.30000000000000004), NewRange(0.3, 1))
+
+	fmt.Println(s.AsSlice())
+
+	// Output:
+	// [{0 1}]
+}
@@ -0,0 +1,69 @@
+package intervalset
+
+import "time"
+
+// UptimeReport summarizes the availability of a service over a measurement window.
+type UptimeReport struct {
+	// Window is the measurement window.
+	Window Period[time.Time]
+
+	// Measured is the duration of the window, excluding maintenance windows.
+	Measured time.Duration
+
+	// Uptime and Downtime are the durations during which the service was up and down within the measured time.
+	Uptime   time.Duration
+	Downtime time.Duration
+
+	// Availability is the percentage of the measured time during which the service was up.
+	Availability float64
+
+	// Incidents holds the downtime of each outage within the measured time, in chronological order.
+	Incidents []time.Duration
+
+	// MTTR is the mean time to recovery, i.e. the average downtime of the incidents.
+	MTTR time.Duration
+
+	// MTBF is the mean time between failures, i.e. the uptime divided by the number of incidents.
+	MTBF time.Duration
+}
+
+// NewUptimeReport returns the uptime report of the given window.
+//
+// Outages are truncated to the window and the maintenance windows are excluded from the measured time,
+// so an outage happening during a maintenance window does not count as downtime. An outage entirely
+// covered by maintenance windows is not reported as an incident. When there are no incidents,
+// the MTTR is zero and the MTBF equals the uptime. The availability of a window with no measured
+// time is 100%.
+func NewUptimeReport(outages *IntervalSet[time.Time], window Period[time.Time], maintenance ...Interval[time.Time]) UptimeReport {
+	excluded := EmptySet[time.Time]().Add(maintenance...)
+	measured := excluded.Complement(window)
+	downtime := outages.Overlaps(window).Difference(excluded)
+
+	r := UptimeReport{
+		Window:       window,
+		Measured:     Duration(measured),
+		Downtime:     Duration(downtime),
+		Availability: 100,
+		Incidents:    make([]time.Duration, 0),
+	}
+
+	r.Uptime = r.Measured - r.Downtime
+
+	if r.Measured > 0 {
+		r.Availability = float64(r.Uptime) / float64(r.Measured) * 100
+	}
+
+	for _, v := range outages.Overlaps(window).intervals {
+		if d := Duration(excluded.Complement(v)); d > 0 {
+			r.Incidents = append(r.Incidents, d)
+		}
+	}
+
+	r.MTBF = r.Uptime
+	if n := len(r.Incidents); n > 0 {
+		r.MTTR = r.Downtime / time.Duration(n)
+		r.MTBF = r.Uptime / time.Duration(n)
+	}
+
+	return r
+}
@@ -0,0 +1,110 @@
+package intervalset
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNewUptimeReport(t *testing.T) {
+	at := func(d, h, m int) time.Time {
+		return time.Date(2023, time.November, d, h, m, 0, 0, time.UTC)
+	}
+
+	window := NewPeriod(at(1, 0, 0), at(31, 0, 0))
+
+	outages := EmptySet[time.Time]().Add(
+		NewPeriod(time.Date(2023, time.October, 31, 23, 0, 0, 0, time.UTC), at(1, 1, 0)),
+		NewPeriod(at(10, 12, 0), at(10, 12, 30)),
+		NewPeriod(at(15, 2, 0), at(15, 2, 45)),
+		NewPeriod(at(20, 3, 0), at(20, 4, 0)),
+	)
+
+	maintenance := []Interval[time.Time]{
+		NewPeriod(at(15, 2, 0), at(15, 4, 0)),
+		NewPeriod(at(20, 2, 0), at(20, 3, 30)),
+	}
+
+	r := NewUptimeReport(outages, window, maintenance...)
+
+	measured := 30*24*time.Hour - 3*time.Hour - 30*time.Minute
+	downtime := time.Hour + 30*time.Minute + 30*time.Minute
+
+	if r.Measured != measured {
+		t.Errorf("expected measured time %s, got %s", measured, r.Measured)
+	}
+	if r.Downtime != downtime {
+		t.Errorf("expected downtime %s, got %s", downtime, r.Downtime)
+	}
+	if r.Uptime != measured-downtime {
+		t.Errorf("expected uptime %s, got %s", measured-downtime, r.Uptime)
+	}
+
+	expected := []time.Duration{time.Hour, 30 * time.Minute, 30 * time.Minute}
+	if len(r.Incidents) != len(expected) {
+		t.Fatalf("expected %d incidents, got %v", len(expected), r.Incidents)
+	}
+	for i, d := range expected {
+		if r.Incidents[i] != d {
+			t.Errorf("expected incident %d to last %s, got %s", i, d, r.Incidents[i])
+		}
+	}
+
+	if r.MTTR != downtime/3 {
+		t.Errorf("expected MTTR %s, got %s", downtime/3, r.MTTR)
+	}
+	if r.MTBF != (measured-downtime)/3 {
+		t.Errorf("expected MTBF %s, got %s", (measured-downtime)/3, r.MTBF)
+	}
+
+	a := float64(measured-downtime) / float64(measured) * 100
+	if r.Availability != a {
+		t.Errorf("expected availability %f, got %f", a, r.Availability)
+	}
+}
+
+func TestNewUptimeReport_NoIncidents(t *testing.T) {
+	window := NewPeriod(
+		time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, time.November, 2, 0, 0, 0, 0, time.UTC),
+	)
+
+	r := NewUptimeReport(EmptySet[time.Time](), window)
+
+	if r.Availability != 100 || r.Downtime != 0 || len(r.Incidents) != 0 {
+		t.Errorf("expected a full availability, got %+v", r)
+	}
+	if r.MTTR != 0 || r.MTBF != 24*time.Hour {
+		t.Errorf("expected MTTR 0 and MTBF 24h, got %s and %s", r.MTTR, r.MTBF)
+	}
+
+	r = NewUptimeReport(EmptySet[time.Time]().Add(window), window, window)
+	if r.Availability != 100 || r.Measured != 0 || len(r.Incidents) != 0 {
+		t.Errorf("expected no measured time, got %+v", r)
+	}
+}
+
+func ExampleNewUptimeReport() {
+	window := NewPeriod(
+		time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC),
+	)
+
+	outages := EmptySet[time.Time]().Add(
+		NewPeriod(
+			time.Date(2023, time.November, 10, 12, 0, 0, 0, time.UTC),
+			time.Date(2023, time.November, 10, 12, 36, 0, 0, time.UTC),
+		),
+		NewPeriod(
+			time.Date(2023, time.November, 20, 8, 0, 0, 0, time.UTC),
+			time.Date(2023, time.November, 20, 8, 7, 12, 0, time.UTC),
+		),
+	)
+
+	r := NewUptimeReport(outages, window)
+
+	fmt.Printf("%.3f%% %s %d %s\n", r.Availability, r.Downtime, len(r.Incidents), r.MTTR)
+
+	// Output:
+	// 99.900% 43m12s 2 21m36s
+}
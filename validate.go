@@ -0,0 +1,91 @@
+package intervalset
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvertedBounds is returned when the lower bound of an interval is greater than its upper bound.
+	ErrInvertedBounds = errors.New("intervalset: lower bound greater than upper bound")
+
+	// ErrNaN is returned when a bound of a range is NaN.
+	ErrNaN = errors.New("intervalset: NaN bound")
+
+	// ErrLocationMismatch is returned when the bounds of a period are in different locations.
+	ErrLocationMismatch = errors.New("intervalset: bounds in different locations")
+)
+
+// InvalidPolicy defines how a set handles the invalid intervals given to Add and Sub.
+type InvalidPolicy int
+
+const (
+	// SkipInvalid ignores invalid intervals.
+	SkipInvalid InvalidPolicy = iota
+
+	// NormalizeInvalid swaps the bounds of inverted intervals and ignores the other invalid intervals.
+	NormalizeInvalid
+
+	// PanicOnInvalid panics with the validation error when given an invalid interval.
+	PanicOnInvalid
+)
+
+// SetOption configures a set created by EmptySet.
+// The sets derived from a set, by Difference or Coalesce for instance, keep its options
+// while the sets combining several sets, such as Union or AtLeast, have the options of the first one.
+type SetOption func(*setConfig)
+
+type setConfig struct {
+	invalid   InvalidPolicy
+	tolerance float64
+	maxGap    float64
+}
+
+// WithInvalidPolicy sets how the set handles invalid intervals, SkipInvalid being the default.
+func WithInvalidPolicy(p InvalidPolicy) SetOption {
+	return func(c *setConfig) {
+		c.invalid = p
+	}
+}
+
+// validator is implemented by the intervals able to tell why they are invalid.
+type validator[T any] interface {
+	validate() error
+	normalize() (Interval[T], bool)
+}
+
+// Validate returns an error describing why q is invalid, nil when it is valid.
+// Only ranges and periods are validated, other intervals are deemed valid.
+func Validate[T any](q Interval[T]) error {
+	if v, ok := q.(validator[T]); ok {
+		return v.validate()
+	}
+	return nil
+}
+
+// sanitize returns the interval to add or subtract according to the policy
+// and reports whether there is one.
+func sanitize[T any](policy InvalidPolicy, q Interval[T]) (Interval[T], bool) {
+	v, ok := q.(validator[T])
+	if !ok {
+		return q, true
+	}
+
+	err := v.validate()
+	if err == nil {
+		return q, true
+	}
+
+	switch policy {
+	case NormalizeInvalid:
+		return v.normalize()
+	case PanicOnInvalid:
+		panic(fmt.Sprintf("%s: %v", err, q))
+	}
+	return q, false
+}
+
+// isEmptyInterval reports whether the limits of q are equal, in which case q covers nothing.
+func isEmptyInterval[T any](q Interval[T]) bool {
+	return compareMin(q, q.Max()) == 0
+}
@@ -0,0 +1,190 @@
+package intervalset
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMakeRange(t *testing.T) {
+	var table = []struct {
+		l, u float64
+		err  error
+	}{
+		{1, 5, nil},
+		{1, 1, nil},
+		{5, 1, ErrInvertedBounds},
+		{math.NaN(), 1, ErrNaN},
+		{1, math.NaN(), ErrNaN},
+		{math.Inf(-1), math.Inf(1), nil},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			_, err := MakeRange(tc.l, tc.u)
+			if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+				t.Errorf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestMakePeriod(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	s := time.Date(2023, time.December, 1, 9, 0, 0, 0, time.UTC)
+	e := time.Date(2023, time.December, 1, 17, 0, 0, 0, time.UTC)
+
+	var table = []struct {
+		s, e time.Time
+		err  error
+	}{
+		{s, e, nil},
+		{s, s, nil},
+		{e, s, ErrInvertedBounds},
+		{s, e.In(paris), ErrLocationMismatch},
+		{s.In(paris), e.In(paris), nil},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			_, err := MakePeriod(tc.s, tc.e)
+			if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+				t.Errorf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestIntervalSet_InvalidPolicy(t *testing.T) {
+	var table = []struct {
+		policy   InvalidPolicy
+		expected *IntervalSet[int]
+	}{
+		{SkipInvalid, genExpectedRangeSet([]Interval[int]{NewRange(1, 3), NewRange(10, 12)})},
+		{NormalizeInvalid, genExpectedRangeSet([]Interval[int]{NewRange(1, 5), NewRange(10, 11)})},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			s := EmptySet[int](WithInvalidPolicy(tc.policy)).
+				Add(NewRange(1, 3), NewRange(5, 3), NewRange(10, 12)).
+				Sub(NewRange(12, 11))
+
+			if !s.Equal(tc.expected) {
+				t.Errorf("both sets should be equal, expected %v, got %v", tc.expected, s.AsSlice())
+			}
+		})
+	}
+
+	// NaN bounds cannot be normalized
+	f := EmptySet[float64](WithInvalidPolicy(NormalizeInvalid)).Add(NewRange(math.NaN(), 1), NewRange(2.0, 1.0))
+	if !f.Equal(EmptySet[float64]().Add(NewRange(1.0, 2.0))) {
+		t.Errorf("expected the NaN range to be skipped, got %v", f.AsSlice())
+	}
+
+	if _, ok := EmptySet[int]().TryAdd(NewRange(3, 1)); ok {
+		t.Errorf("expected an invalid interval not to be added")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected the set to panic")
+		}
+	}()
+	EmptySet[int](WithInvalidPolicy(PanicOnInvalid)).Add(NewRange(3, 1))
+}
+
+func TestIntervalSet_CopiesKeepOptions(t *testing.T) {
+	opts := []SetOption{WithTolerance(1e-6), WithMaxGap(0.5), WithInvalidPolicy(NormalizeInvalid)}
+	newSet := func() *IntervalSet[float64] {
+		return EmptySet[float64](opts...).Add(NewRange(0, 0.3))
+	}
+
+	// adjoining within the tolerance, within the maximum gap and inverted
+	intervals := []Interval[float64]{NewRange(0.3000001, 1), NewRange(2, 3.0), NewRange(4, 3.4)}
+	expected := genExpectedFloatSet([]Interval[float64]{NewRange(0, 1.0), NewRange(2, 4.0)})
+
+	var table = []func() *IntervalSet[float64]{
+		func() *IntervalSet[float64] {
+			return newSet().Add(intervals...)
+		},
+		func() *IntervalSet[float64] {
+			return newSet().Sync().Add(intervals...).Snapshot()
+		},
+		func() *IntervalSet[float64] {
+			return newSet().Sync().Snapshot().Add(intervals...)
+		},
+		func() *IntervalSet[float64] {
+			return NewJournal(newSet()).Add(intervals...).Set()
+		},
+		func() *IntervalSet[float64] {
+			return newSet().Persistent().Add(NewRange(5, 6.0)).Mutable().Sub(NewRange(5, 6.0)).Add(intervals...)
+		},
+		// derived sets
+		func() *IntervalSet[float64] {
+			return newSet().Difference(EmptySet[float64]()).Add(intervals...)
+		},
+		func() *IntervalSet[float64] {
+			return EmptySet[float64](opts...).Complement(NewRange(0, 0.3)).Add(intervals...)
+		},
+		func() *IntervalSet[float64] {
+			return newSet().Overlaps(NewRange(-1, 1.0)).Add(intervals...)
+		},
+		func() *IntervalSet[float64] {
+			return Coalesce(newSet(), 0).Add(intervals...)
+		},
+		func() *IntervalSet[float64] {
+			return DropShorterThan(newSet(), 0).Add(intervals...)
+		},
+		func() *IntervalSet[float64] {
+			return Quantize(newSet(), 0.1, QuantizeOutward, 0).Add(intervals...)
+		},
+		func() *IntervalSet[float64] {
+			return Union(newSet(), EmptySet[float64]()).Add(intervals...)
+		},
+		func() *IntervalSet[float64] {
+			return Intersection(newSet(), EmptySet[float64]().Add(NewRange(0, 1.0))).Add(intervals...)
+		},
+		func() *IntervalSet[float64] {
+			return AtLeast(1, newSet(), EmptySet[float64]()).Add(intervals...)
+		},
+		func() *IntervalSet[float64] {
+			s := EmptySet[float64](opts...)
+			_ = s.Scan("{[0,0.3)}")
+			return s.Add(intervals...)
+		},
+	}
+
+	for i, f := range table {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			if got := f(); !got.Equal(expected) {
+				t.Errorf("both sets should be equal, expected %v, got %v", expected.AsSlice(), got.AsSlice())
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s := time.Date(2023, time.December, 1, 9, 0, 0, 0, time.UTC)
+
+	if err := Validate[time.Time](NewPeriod(s.Add(time.Hour), s)); !errors.Is(err, ErrInvertedBounds) {
+		t.Errorf("expected %v, got %v", ErrInvertedBounds, err)
+	}
+	if err := Validate[int](NewRange(1, 2)); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func ExampleMakeRange() {
+	_, err := MakeRange(5, 1)
+	fmt.Println(err)
+
+	// Output:
+	// intervalset: lower bound greater than upper bound: [5, 1]
+}